@@ -0,0 +1,23 @@
+package network
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const appConfigDirName = "PrivacyBuddy"
+
+// appConfigDir returns the PrivacyBuddy directory inside the user config dir,
+// creating it (and the optional sub directory) if necessary.
+func appConfigDir(subDirs ...string) (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user config directory: %w", err)
+	}
+	dir := filepath.Join(append([]string{configDir, appConfigDirName}, subDirs...)...)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create app config directory: %w", err)
+	}
+	return dir, nil
+}
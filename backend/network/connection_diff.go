@@ -0,0 +1,71 @@
+package network
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ConnectionStateChange is a connection whose status differs between two snapshots.
+type ConnectionStateChange struct {
+	Connection     NetworkConnection
+	PreviousStatus string
+}
+
+// ConnectionDiff is the difference between two connection tables.
+type ConnectionDiff struct {
+	Opened       []NetworkConnection
+	Closed       []NetworkConnection // As seen in the previous snapshot
+	StateChanged []ConnectionStateChange
+}
+
+// Empty reports whether both snapshots contained the same connections in the same states.
+func (d ConnectionDiff) Empty() bool {
+	return len(d.Opened) == 0 && len(d.Closed) == 0 && len(d.StateChanged) == 0
+}
+
+// ConnectionKey identifies a connection across snapshots by protocol, endpoints and socket.
+// The socket inode (or the PID where the platform reports no inode) distinguishes a
+// connection from a new one that reuses the same endpoints, e.g. an unconnected UDP
+// socket bound to the same port by another process.
+func ConnectionKey(conn NetworkConnection) string {
+	socket := fmt.Sprintf("inode:%d", conn.Inode)
+	if conn.Inode == 0 {
+		socket = fmt.Sprintf("pid:%d", conn.PID)
+	}
+	return fmt.Sprintf("%d/%d/%s %s:%d>%s:%d %s", conn.Family, conn.Type, conn.Protocol, conn.LocalIP, conn.LocalPort, conn.RemoteIP, conn.RemotePort, socket)
+}
+
+// IndexConnections maps the connections of a snapshot by ConnectionKey.
+func IndexConnections(conns []NetworkConnection) map[string]NetworkConnection {
+	index := make(map[string]NetworkConnection, len(conns))
+	for _, conn := range conns {
+		index[ConnectionKey(conn)] = conn
+	}
+	return index
+}
+
+// DiffConnections compares two snapshots of the connection table. The results are
+// sorted by ConnectionKey so that events are emitted in a stable order.
+func DiffConnections(previous, current map[string]NetworkConnection) ConnectionDiff {
+	var diff ConnectionDiff
+	for key, conn := range current {
+		old, existed := previous[key]
+		if !existed {
+			diff.Opened = append(diff.Opened, conn)
+		} else if old.Status != conn.Status {
+			diff.StateChanged = append(diff.StateChanged, ConnectionStateChange{Connection: conn, PreviousStatus: old.Status})
+		}
+	}
+	for key, conn := range previous {
+		if _, exists := current[key]; !exists {
+			diff.Closed = append(diff.Closed, conn)
+		}
+	}
+
+	sort.Slice(diff.Opened, func(i, j int) bool { return ConnectionKey(diff.Opened[i]) < ConnectionKey(diff.Opened[j]) })
+	sort.Slice(diff.Closed, func(i, j int) bool { return ConnectionKey(diff.Closed[i]) < ConnectionKey(diff.Closed[j]) })
+	sort.Slice(diff.StateChanged, func(i, j int) bool {
+		return ConnectionKey(diff.StateChanged[i].Connection) < ConnectionKey(diff.StateChanged[j].Connection)
+	})
+	return diff
+}
@@ -0,0 +1,797 @@
+package network
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	connectionHistoryDirName       = "history"
+	connectionHistoryFilePrefix    = "connections-"
+	connectionHistoryFileExt       = ".jsonl"
+	connectionHistorySettingsFile  = "settings.json"
+	connectionHistoryOpenFile      = "open.json" // Connections open when recording stopped
+	connectionHistoryDayLayout     = "2006-01-02"
+	connectionHistoryPruneInterval = time.Hour
+	connectionHistoryMaxLineSize   = 1 << 20
+)
+
+var defaultConnectionHistorySettings = ConnectionHistorySettings{
+	IntervalSeconds: 2,
+	RetentionDays:   30,
+	MaxSizeMB:       100,
+}
+
+var connectionHistoryCSVHeader = []string{
+	"OpenedAt", "ClosedAt", "DurationMs", "Protocol", "FamilyName", "LocalIP", "LocalPort",
+	"RemoteIP", "RemotePort", "RemoteHostname", "PID", "ProcessName", "ExecutablePath", "LastStatus",
+}
+
+type openConnectionRecord struct {
+	conn     NetworkConnection
+	record   ConnectionRecord
+	openedAt time.Time
+	lastSeen time.Time
+}
+
+// persistedOpenConnection is an open connection as stored in connectionHistoryOpenFile.
+type persistedOpenConnection struct {
+	Connection NetworkConnection `json:"Connection"`
+	Record     ConnectionRecord  `json:"Record"`
+	OpenedAt   time.Time         `json:"OpenedAt"`
+	LastSeen   time.Time         `json:"LastSeen"`
+}
+
+// ConnectionHistoryService records the lifecycle of every connection with a remote endpoint
+// to one JSON Lines file per day in the config directory. Records are written when a
+// connection closes; open connections are kept in memory and included in queries. When
+// recording stops, the open connections are saved and resumed on the next start, so that
+// only closes that were actually observed are written.
+type ConnectionHistoryService struct {
+	connectionService NetworkConnectionService
+
+	mu        sync.Mutex
+	settings  ConnectionHistorySettings
+	stop      context.CancelFunc
+	open      map[string]*openConnectionRecord
+	lastPrune time.Time
+}
+
+// NewConnectionHistoryService creates a new ConnectionHistoryService with the persisted settings.
+func NewConnectionHistoryService(connectionService NetworkConnectionService) *ConnectionHistoryService {
+	s := &ConnectionHistoryService{
+		connectionService: connectionService,
+		settings:          defaultConnectionHistorySettings,
+		open:              make(map[string]*openConnectionRecord),
+	}
+	if err := s.loadSettings(); err != nil {
+		log.Printf("WARN: Could not load connection history settings: %v", err)
+	}
+	if err := s.loadOpenConnections(); err != nil {
+		log.Printf("WARN: Could not load open connections: %v", err)
+	}
+	return s
+}
+
+// WailsInit resumes recording if it was enabled when the application was closed.
+func (s *ConnectionHistoryService) WailsInit(ctx context.Context) {
+	s.mu.Lock()
+	enabled := s.settings.Enabled
+	s.mu.Unlock()
+	if enabled {
+		if err := s.StartConnectionHistory(0); err != nil {
+			log.Printf("WARN: Could not resume connection history: %v", err)
+		}
+	}
+}
+
+// StartConnectionHistory starts recording with a poll interval of intervalSeconds
+// (0 keeps the configured interval). Recording resumes automatically on the next start.
+func (s *ConnectionHistoryService) StartConnectionHistory(intervalSeconds int) error {
+	if intervalSeconds < 0 {
+		return fmt.Errorf("interval must not be negative")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil {
+		return fmt.Errorf("connection history is already running")
+	}
+	if intervalSeconds > 0 {
+		s.settings.IntervalSeconds = intervalSeconds
+	}
+	s.settings.Enabled = true
+	if err := s.saveSettingsLocked(); err != nil {
+		return err
+	}
+	s.pruneLocked(time.Now())
+	// The open connections are in memory until stopLocked saves them again
+	if err := removeOpenConnectionsFile(); err != nil {
+		log.Printf("WARN: Could not remove open connections: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stop = cancel
+	interval := time.Duration(s.settings.IntervalSeconds) * time.Second
+	go s.recordLoop(ctx, interval)
+	log.Printf("Connection history started (interval %s).", interval)
+	return nil
+}
+
+// StopConnectionHistory stops recording. Connections that are still open are kept and
+// either resumed or recorded as closed when recording restarts.
+func (s *ConnectionHistoryService) StopConnectionHistory() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopLocked()
+	s.settings.Enabled = false
+	return s.saveSettingsLocked()
+}
+
+// Shutdown stops recording without changing the Enabled setting, so that recording
+// resumes on the next start.
+func (s *ConnectionHistoryService) Shutdown() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopLocked()
+}
+
+// GetConnectionHistoryStatus returns the recorder state, settings and size of the history.
+func (s *ConnectionHistoryService) GetConnectionHistoryStatus() ConnectionHistoryStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := ConnectionHistoryStatus{
+		Running:         s.stop != nil,
+		Settings:        s.settings,
+		OpenConnections: len(s.open),
+	}
+	files, _ := connectionHistoryFiles()
+	for _, file := range files {
+		status.SizeBytes += file.size
+	}
+	if len(files) > 0 {
+		status.OldestDay = files[0].day
+	}
+	return status
+}
+
+// SetConnectionHistoryRetention sets how many days and megabytes of history are kept
+// and prunes the history accordingly.
+func (s *ConnectionHistoryService) SetConnectionHistoryRetention(retentionDays int, maxSizeMB int) error {
+	if retentionDays < 1 || maxSizeMB < 1 {
+		return fmt.Errorf("retention days and maximum size must be at least 1")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.settings.RetentionDays = retentionDays
+	s.settings.MaxSizeMB = maxSizeMB
+	if err := s.saveSettingsLocked(); err != nil {
+		return err
+	}
+	s.lastPrune = time.Time{}
+	s.pruneLocked(time.Now())
+	return nil
+}
+
+// ClearConnectionHistory deletes all recorded history. Open connections are kept.
+func (s *ConnectionHistoryService) ClearConnectionHistory() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	files, err := connectionHistoryFiles()
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := os.Remove(file.path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to delete %s: %w", file.path, err)
+		}
+	}
+	return nil
+}
+
+// QueryConnectionHistory returns the recorded and currently open connections that match
+// the query, most recently opened first.
+func (s *ConnectionHistoryService) QueryConnectionHistory(query ConnectionHistoryQuery) ([]ConnectionRecord, error) {
+	since, until, err := connectionHistoryRange(query, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if query.Limit < 0 {
+		return nil, fmt.Errorf("limit must not be negative")
+	}
+	match := newConnectionRecordFilter(query, since, until)
+
+	s.mu.Lock()
+	records, err := readConnectionHistory(since, match)
+	now := time.Now()
+	for _, open := range s.open {
+		record := open.record
+		end := now
+		if s.stop == nil {
+			end = open.lastSeen // Not observed since recording stopped
+		}
+		record.DurationMs = end.Sub(open.openedAt).Milliseconds()
+		if match(record) {
+			records = append(records, record)
+		}
+	}
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(records, func(i, j int) bool { return records[i].OpenedAt > records[j].OpenedAt })
+	if query.Limit > 0 && len(records) > query.Limit {
+		records = records[:query.Limit]
+	}
+	return records, nil
+}
+
+// GetContactedHosts aggregates the matching history by remote host, e.g., all hosts
+// contacted by one process in the last 24 hours. Hosts seen most recently come first.
+func (s *ConnectionHistoryService) GetContactedHosts(query ConnectionHistoryQuery) ([]ContactedHost, error) {
+	limit := query.Limit
+	query.Limit = 0
+	records, err := s.QueryConnectionHistory(query)
+	if err != nil {
+		return nil, err
+	}
+
+	type hostAggregate struct {
+		host      ContactedHost
+		ports     map[uint32]struct{}
+		processes map[string]struct{}
+	}
+	hosts := make(map[string]*hostAggregate)
+	now := time.Now().Format(time.RFC3339)
+	for _, record := range records {
+		agg, ok := hosts[record.RemoteIP]
+		if !ok {
+			agg = &hostAggregate{
+				host:      ContactedHost{RemoteIP: record.RemoteIP, FirstSeen: record.OpenedAt},
+				ports:     make(map[uint32]struct{}),
+				processes: make(map[string]struct{}),
+			}
+			hosts[record.RemoteIP] = agg
+		}
+		lastSeen := record.ClosedAt
+		if lastSeen == "" {
+			lastSeen = now
+		}
+		agg.host.Connections++
+		if record.RemoteHostname != "" {
+			agg.host.RemoteHostname = record.RemoteHostname
+		}
+		if record.OpenedAt < agg.host.FirstSeen {
+			agg.host.FirstSeen = record.OpenedAt
+		}
+		if lastSeen > agg.host.LastSeen {
+			agg.host.LastSeen = lastSeen
+		}
+		agg.ports[record.RemotePort] = struct{}{}
+		agg.processes[record.ProcessName] = struct{}{}
+	}
+
+	result := make([]ContactedHost, 0, len(hosts))
+	for _, agg := range hosts {
+		for port := range agg.ports {
+			agg.host.Ports = append(agg.host.Ports, port)
+		}
+		sort.Slice(agg.host.Ports, func(i, j int) bool { return agg.host.Ports[i] < agg.host.Ports[j] })
+		agg.host.Processes = sortedKeys(agg.processes)
+		result = append(result, agg.host)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].LastSeen != result[j].LastSeen {
+			return result[i].LastSeen > result[j].LastSeen
+		}
+		return compareIPStrings(result[i].RemoteIP, result[j].RemoteIP) < 0
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// ExportConnectionHistory writes the matching records as "csv" or "json" to the path
+// chosen in the frontend.
+func (s *ConnectionHistoryService) ExportConnectionHistory(query ConnectionHistoryQuery, format string, filePath string) error {
+	format = strings.ToLower(format)
+	if format != "csv" && format != "json" {
+		return fmt.Errorf("unknown export format '%s'", format)
+	}
+	records, err := s.QueryConnectionHistory(query)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to create export file: %w", err)
+	}
+	if err := writeConnectionRecords(file, records, format); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+	return nil
+}
+
+// writeConnectionRecords writes records as "csv" or "json".
+func writeConnectionRecords(w io.Writer, records []ConnectionRecord, format string) error {
+	if format == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(records)
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Write(connectionHistoryCSVHeader)
+	for _, r := range records {
+		writer.Write([]string{
+			r.OpenedAt, r.ClosedAt, strconv.FormatInt(r.DurationMs, 10), r.Protocol, r.FamilyName,
+			r.LocalIP, strconv.FormatUint(uint64(r.LocalPort), 10),
+			r.RemoteIP, strconv.FormatUint(uint64(r.RemotePort), 10), r.RemoteHostname,
+			strconv.FormatInt(int64(r.PID), 10), r.ProcessName, r.ExecutablePath, r.LastStatus,
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func (s *ConnectionHistoryService) recordLoop(ctx context.Context, interval time.Duration) {
+	s.recordSnapshot(ctx)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.recordSnapshot(ctx)
+		}
+	}
+}
+
+// recordSnapshot diffs the connection table against the open records and writes the
+// records of closed connections.
+func (s *ConnectionHistoryService) recordSnapshot(ctx context.Context) {
+	conns, err := s.connectionService.GetConnections()
+	if err != nil {
+		log.Printf("WARN: Connection history could not read connections: %v", err)
+		return
+	}
+	recordable := conns[:0]
+	for _, conn := range conns {
+		if isRecordableConnection(conn) {
+			recordable = append(recordable, conn)
+		}
+	}
+	current := IndexConnections(recordable)
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ctx.Err() != nil {
+		return // Stopped while reading the connection table
+	}
+
+	previous := make(map[string]NetworkConnection, len(s.open))
+	for key, open := range s.open {
+		previous[key] = open.conn
+	}
+	diff := DiffConnections(previous, current)
+
+	var closed []ConnectionRecord
+	for _, conn := range diff.Closed {
+		key := ConnectionKey(conn)
+		closed = append(closed, s.open[key].closedRecord())
+		delete(s.open, key)
+	}
+	processes := GetProcessContextService()
+	for _, conn := range diff.Opened {
+		record := ConnectionRecord{
+			Protocol:    ConnectionProtocol(conn),
+			FamilyName:  connectionFamilyName(conn),
+			LocalIP:     conn.LocalIP,
+			LocalPort:   conn.LocalPort,
+			RemoteIP:    conn.RemoteIP,
+			RemotePort:  conn.RemotePort,
+			PID:         conn.PID,
+			ProcessName: conn.ProcessName,
+			OpenedAt:    now.Format(time.RFC3339),
+		}
+		if conn.PID > 0 {
+			if process := processes.Lookup(conn.PID); process != nil {
+				record.ExecutablePath = process.ExecutablePath
+			}
+		}
+		s.open[ConnectionKey(conn)] = &openConnectionRecord{record: record, openedAt: now}
+	}
+	rdns := GetReverseDNSService()
+	for key, conn := range current {
+		open := s.open[key]
+		open.conn = conn
+		open.lastSeen = now
+		open.record.LastStatus = conn.Status
+		if hostname := rdns.Hostname(conn.RemoteIP); hostname != "" {
+			open.record.RemoteHostname = hostname
+		}
+	}
+
+	if err := appendConnectionRecords(closed); err != nil {
+		log.Printf("WARN: Could not write connection history: %v", err)
+	}
+	s.pruneLocked(now)
+}
+
+// stopLocked stops the record loop and saves the open connections, which stay in memory.
+// The caller must hold s.mu.
+func (s *ConnectionHistoryService) stopLocked() {
+	if s.stop == nil {
+		return
+	}
+	s.stop()
+	s.stop = nil
+
+	if err := s.saveOpenConnectionsLocked(); err != nil {
+		log.Printf("WARN: Could not save open connections: %v", err)
+	}
+	log.Println("Connection history stopped.")
+}
+
+// saveOpenConnectionsLocked writes the open connections to connectionHistoryOpenFile.
+// The caller must hold s.mu.
+func (s *ConnectionHistoryService) saveOpenConnectionsLocked() error {
+	dir, err := appConfigDir(connectionHistoryDirName)
+	if err != nil {
+		return err
+	}
+	open := make([]persistedOpenConnection, 0, len(s.open))
+	for _, o := range s.open {
+		open = append(open, persistedOpenConnection{Connection: o.conn, Record: o.record, OpenedAt: o.openedAt, LastSeen: o.lastSeen})
+	}
+	sort.Slice(open, func(i, j int) bool { return open[i].OpenedAt.Before(open[j].OpenedAt) })
+	data, err := json.Marshal(open)
+	if err != nil {
+		return fmt.Errorf("failed to marshal open connections: %w", err)
+	}
+	path := filepath.Join(dir, connectionHistoryOpenFile)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", tmpPath, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+	return nil
+}
+
+// loadOpenConnections reads the connections saved by saveOpenConnectionsLocked.
+func (s *ConnectionHistoryService) loadOpenConnections() error {
+	dir, err := appConfigDir(connectionHistoryDirName)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, connectionHistoryOpenFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	var open []persistedOpenConnection
+	if err := json.Unmarshal(data, &open); err != nil {
+		return fmt.Errorf("failed to unmarshal open connections: %w", err)
+	}
+	for _, o := range open {
+		s.open[ConnectionKey(o.Connection)] = &openConnectionRecord{conn: o.Connection, record: o.Record, openedAt: o.OpenedAt, lastSeen: o.LastSeen}
+	}
+	return nil
+}
+
+// removeOpenConnectionsFile deletes connectionHistoryOpenFile once the connections are resumed.
+func removeOpenConnectionsFile() error {
+	dir, err := appConfigDir(connectionHistoryDirName)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filepath.Join(dir, connectionHistoryOpenFile)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// pruneLocked deletes history files older than the retention period and then the oldest
+// files until the history fits the size limit. It runs at most once per
+// connectionHistoryPruneInterval. The caller must hold s.mu.
+func (s *ConnectionHistoryService) pruneLocked(now time.Time) {
+	if now.Sub(s.lastPrune) < connectionHistoryPruneInterval {
+		return
+	}
+	s.lastPrune = now
+
+	files, err := connectionHistoryFiles()
+	if err != nil {
+		log.Printf("WARN: Could not prune connection history: %v", err)
+		return
+	}
+	cutoff := now.AddDate(0, 0, -s.settings.RetentionDays).Format(connectionHistoryDayLayout)
+	maxSize := int64(s.settings.MaxSizeMB) << 20
+	var total int64
+	for _, file := range files {
+		total += file.size
+	}
+	for i, file := range files {
+		if (file.day >= cutoff && total <= maxSize) || i == len(files)-1 {
+			break // The newest file is kept even if it exceeds the size limit alone
+		}
+		if err := os.Remove(file.path); err != nil {
+			log.Printf("WARN: Could not delete %s: %v", file.path, err)
+			continue
+		}
+		total -= file.size
+	}
+}
+
+// closedRecord completes the record of a connection that was last seen at lastSeen.
+func (o *openConnectionRecord) closedRecord() ConnectionRecord {
+	record := o.record
+	record.ClosedAt = o.lastSeen.Format(time.RFC3339)
+	record.DurationMs = o.lastSeen.Sub(o.openedAt).Milliseconds()
+	return record
+}
+
+func (s *ConnectionHistoryService) loadSettings() error {
+	dir, err := appConfigDir(connectionHistoryDirName)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, connectionHistorySettingsFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	settings := defaultConnectionHistorySettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return fmt.Errorf("failed to unmarshal connection history settings: %w", err)
+	}
+	if settings.IntervalSeconds < 1 {
+		settings.IntervalSeconds = defaultConnectionHistorySettings.IntervalSeconds
+	}
+	if settings.RetentionDays < 1 {
+		settings.RetentionDays = defaultConnectionHistorySettings.RetentionDays
+	}
+	if settings.MaxSizeMB < 1 {
+		settings.MaxSizeMB = defaultConnectionHistorySettings.MaxSizeMB
+	}
+	s.settings = settings
+	return nil
+}
+
+// saveSettingsLocked persists the settings. The caller must hold s.mu.
+func (s *ConnectionHistoryService) saveSettingsLocked() error {
+	dir, err := appConfigDir(connectionHistoryDirName)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s.settings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal connection history settings: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, connectionHistorySettingsFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write connection history settings: %w", err)
+	}
+	return nil
+}
+
+// isRecordableConnection reports whether a socket is a TCP or UDP connection with a remote endpoint.
+func isRecordableConnection(conn NetworkConnection) bool {
+	protocol := ConnectionProtocol(conn)
+	if protocol != "tcp" && protocol != "udp" {
+		return false
+	}
+	if conn.RemotePort == 0 || conn.Status == "LISTEN" {
+		return false
+	}
+	ip := net.ParseIP(conn.RemoteIP)
+	return ip != nil && !ip.IsUnspecified()
+}
+
+// connectionHistoryRange resolves Since, Until and Hours of a query; a zero time means unbounded.
+func connectionHistoryRange(query ConnectionHistoryQuery, now time.Time) (time.Time, time.Time, error) {
+	var since, until time.Time
+	var err error
+	switch {
+	case query.Since != "":
+		if since, err = time.Parse(time.RFC3339, query.Since); err != nil {
+			return since, until, fmt.Errorf("invalid since '%s': %w", query.Since, err)
+		}
+	case query.Hours < 0:
+		return since, until, fmt.Errorf("hours must not be negative")
+	case query.Hours > 0:
+		since = now.Add(-time.Duration(query.Hours) * time.Hour)
+	}
+	if query.Until != "" {
+		if until, err = time.Parse(time.RFC3339, query.Until); err != nil {
+			return since, until, fmt.Errorf("invalid until '%s': %w", query.Until, err)
+		}
+	}
+	return since, until, nil
+}
+
+// newConnectionRecordFilter compiles a query into a predicate. A record matches the time
+// range if the connection was open at any time within it.
+func newConnectionRecordFilter(query ConnectionHistoryQuery, since, until time.Time) func(ConnectionRecord) bool {
+	processName := strings.ToLower(query.ProcessName)
+	remoteHost := strings.ToLower(query.RemoteHost)
+	protocol := strings.ToLower(query.Protocol)
+
+	return func(record ConnectionRecord) bool {
+		if processName != "" && !strings.Contains(strings.ToLower(record.ProcessName), processName) {
+			return false
+		}
+		if query.PID != 0 && record.PID != query.PID {
+			return false
+		}
+		if remoteHost != "" && !strings.Contains(record.RemoteIP, remoteHost) &&
+			!strings.Contains(strings.ToLower(record.RemoteHostname), remoteHost) {
+			return false
+		}
+		if query.RemotePort != 0 && record.RemotePort != query.RemotePort {
+			return false
+		}
+		if protocol != "" && record.Protocol != protocol {
+			return false
+		}
+		if !since.IsZero() && record.ClosedAt != "" {
+			if closedAt, err := time.Parse(time.RFC3339, record.ClosedAt); err == nil && closedAt.Before(since) {
+				return false
+			}
+		}
+		if !until.IsZero() {
+			if openedAt, err := time.Parse(time.RFC3339, record.OpenedAt); err == nil && openedAt.After(until) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+type connectionHistoryFile struct {
+	path string
+	day  string
+	size int64
+}
+
+// connectionHistoryFiles lists the daily history files, oldest first.
+func connectionHistoryFiles() ([]connectionHistoryFile, error) {
+	dir, err := appConfigDir(connectionHistoryDirName)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read connection history directory: %w", err)
+	}
+
+	var files []connectionHistoryFile
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, connectionHistoryFilePrefix) || !strings.HasSuffix(name, connectionHistoryFileExt) {
+			continue
+		}
+		day := strings.TrimSuffix(strings.TrimPrefix(name, connectionHistoryFilePrefix), connectionHistoryFileExt)
+		if _, err := time.Parse(connectionHistoryDayLayout, day); err != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, connectionHistoryFile{path: filepath.Join(dir, name), day: day, size: info.Size()})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].day < files[j].day })
+	return files, nil
+}
+
+// appendConnectionRecords appends records to the history file of the day they were closed.
+func appendConnectionRecords(records []ConnectionRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	dir, err := appConfigDir(connectionHistoryDirName)
+	if err != nil {
+		return err
+	}
+
+	byDay := make(map[string][]byte)
+	for _, record := range records {
+		line, err := json.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("failed to marshal connection record: %w", err)
+		}
+		day := time.Now().Format(connectionHistoryDayLayout)
+		if closedAt, err := time.Parse(time.RFC3339, record.ClosedAt); err == nil {
+			day = closedAt.Local().Format(connectionHistoryDayLayout)
+		}
+		byDay[day] = append(append(byDay[day], line...), '\n')
+	}
+
+	for day, data := range byDay {
+		path := filepath.Join(dir, connectionHistoryFilePrefix+day+connectionHistoryFileExt)
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		_, err = file.Write(data)
+		file.Close()
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// readConnectionHistory reads all records of files that can contain connections closed
+// after since and returns those accepted by match. Malformed lines are skipped.
+func readConnectionHistory(since time.Time, match func(ConnectionRecord) bool) ([]ConnectionRecord, error) {
+	files, err := connectionHistoryFiles()
+	if err != nil {
+		return nil, err
+	}
+	firstDay := ""
+	if !since.IsZero() {
+		firstDay = since.Local().Format(connectionHistoryDayLayout)
+	}
+
+	var records []ConnectionRecord
+	for _, historyFile := range files {
+		if historyFile.day < firstDay {
+			continue
+		}
+		file, err := os.Open(historyFile.path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", historyFile.path, err)
+		}
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 64*1024), connectionHistoryMaxLineSize)
+		for scanner.Scan() {
+			var record ConnectionRecord
+			if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+				continue
+			}
+			if match(record) {
+				records = append(records, record)
+			}
+		}
+		err = scanner.Err()
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", historyFile.path, err)
+		}
+	}
+	return records, nil
+}
@@ -0,0 +1,218 @@
+package network
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+)
+
+// QueryConnectionList applies a ConnectionQuery to a connection list.
+func QueryConnectionList(conns []NetworkConnection, query ConnectionQuery) (*ConnectionQueryResult, error) {
+	filter, err := newConnectionFilter(query)
+	if err != nil {
+		return nil, err
+	}
+	less, err := connectionLess(query.SortBy)
+	if err != nil {
+		return nil, err
+	}
+	if query.Offset < 0 || query.Limit < 0 {
+		return nil, fmt.Errorf("offset and limit must not be negative")
+	}
+
+	matched := make([]NetworkConnection, 0, len(conns))
+	for _, conn := range conns {
+		if filter(conn) {
+			matched = append(matched, conn)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		if query.SortDescending {
+			return less(matched[j], matched[i])
+		}
+		return less(matched[i], matched[j])
+	})
+
+	result := &ConnectionQueryResult{Total: len(matched), Offset: query.Offset, Limit: query.Limit}
+	switch query.GroupBy {
+	case "":
+		start, end := pageBounds(len(matched), query.Offset, query.Limit)
+		result.Connections = matched[start:end]
+	case "process", "remoteHost":
+		groups := groupConnections(matched, query.GroupBy)
+		result.TotalGroups = len(groups)
+		start, end := pageBounds(len(groups), query.Offset, query.Limit)
+		result.Groups = groups[start:end]
+	default:
+		return nil, fmt.Errorf("unknown group '%s'", query.GroupBy)
+	}
+	return result, nil
+}
+
+// newConnectionFilter compiles the filter fields of a query into a predicate.
+func newConnectionFilter(query ConnectionQuery) (func(NetworkConnection) bool, error) {
+	var remoteNet *net.IPNet
+	if query.RemoteCIDR != "" {
+		cidr := query.RemoteCIDR
+		if !strings.Contains(cidr, "/") {
+			if ip := net.ParseIP(cidr); ip != nil && ip.To4() != nil {
+				cidr += "/32"
+			} else {
+				cidr += "/128"
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid remote CIDR '%s': %w", query.RemoteCIDR, err)
+		}
+		remoteNet = ipNet
+	}
+	if query.Family != "" && query.Family != "IPv4" && query.Family != "IPv6" {
+		return nil, fmt.Errorf("unknown family '%s'", query.Family)
+	}
+	processName := strings.ToLower(query.ProcessName)
+	states := stringSet(query.States, strings.ToUpper)
+	protocols := stringSet(query.Protocols, strings.ToLower)
+
+	return func(conn NetworkConnection) bool {
+		if processName != "" && !strings.Contains(strings.ToLower(conn.ProcessName), processName) {
+			return false
+		}
+		if query.PID != 0 && conn.PID != query.PID {
+			return false
+		}
+		if len(states) > 0 {
+			if _, ok := states[strings.ToUpper(conn.Status)]; !ok {
+				return false
+			}
+		}
+		if len(protocols) > 0 {
+			if _, ok := protocols[ConnectionProtocol(conn)]; !ok {
+				return false
+			}
+		}
+		if query.LocalPort != 0 && conn.LocalPort != query.LocalPort {
+			return false
+		}
+		if query.RemotePort != 0 && conn.RemotePort != query.RemotePort {
+			return false
+		}
+		if remoteNet != nil {
+			ip := net.ParseIP(conn.RemoteIP)
+			if ip == nil || !remoteNet.Contains(ip) {
+				return false
+			}
+		}
+		if query.Family != "" && connectionFamilyName(conn) != query.Family {
+			return false
+		}
+		return true
+	}, nil
+}
+
+// connectionLess returns the ordering for a sort field; the default is process name.
+func connectionLess(sortBy string) (func(a, b NetworkConnection) bool, error) {
+	switch sortBy {
+	case "", "processName":
+		return func(a, b NetworkConnection) bool {
+			return strings.ToLower(a.ProcessName) < strings.ToLower(b.ProcessName)
+		}, nil
+	case "pid":
+		return func(a, b NetworkConnection) bool { return a.PID < b.PID }, nil
+	case "protocol":
+		return func(a, b NetworkConnection) bool { return ConnectionProtocol(a) < ConnectionProtocol(b) }, nil
+	case "status":
+		return func(a, b NetworkConnection) bool { return a.Status < b.Status }, nil
+	case "localPort":
+		return func(a, b NetworkConnection) bool { return a.LocalPort < b.LocalPort }, nil
+	case "remoteIP":
+		return func(a, b NetworkConnection) bool { return compareIPStrings(a.RemoteIP, b.RemoteIP) < 0 }, nil
+	case "remotePort":
+		return func(a, b NetworkConnection) bool { return a.RemotePort < b.RemotePort }, nil
+	}
+	return nil, fmt.Errorf("unknown sort field '%s'", sortBy)
+}
+
+// groupConnections groups sorted connections, keeping their order within each group.
+// Remote hosts are grouped by IP, as their hostnames are resolved in the background and
+// may change between queries. Groups are ordered by size, then key.
+func groupConnections(conns []NetworkConnection, groupBy string) []ConnectionGroup {
+	index := make(map[string]int)
+	var groups []ConnectionGroup
+	for _, conn := range conns {
+		key := fmt.Sprintf("%d/%s", conn.PID, conn.ProcessName)
+		if groupBy == "remoteHost" {
+			key = conn.RemoteIP
+		}
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, ConnectionGroup{Key: key})
+		}
+		groups[i].Count++
+		groups[i].Connections = append(groups[i].Connections, conn)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return groups[i].Key < groups[j].Key
+	})
+	return groups
+}
+
+// pageBounds returns the slice bounds of a page; limit 0 means no limit.
+func pageBounds(total, offset, limit int) (int, int) {
+	if offset > total {
+		offset = total
+	}
+	end := total
+	if limit > 0 && offset+limit < total {
+		end = offset + limit
+	}
+	return offset, end
+}
+
+// connectionFamilyName returns "IPv4" or "IPv6", also on platforms that do not fill FamilyName.
+func connectionFamilyName(conn NetworkConnection) string {
+	if conn.FamilyName != "" {
+		return conn.FamilyName
+	}
+	if conn.Family == 2 { // AF_INET on all platforms
+		return "IPv4"
+	}
+	return "IPv6"
+}
+
+// compareIPStrings orders IP addresses numerically, IPv4 before IPv6; invalid addresses sort last.
+func compareIPStrings(a, b string) int {
+	ipA, ipB := net.ParseIP(a), net.ParseIP(b)
+	switch {
+	case ipA == nil && ipB == nil:
+		return strings.Compare(a, b)
+	case ipA == nil:
+		return 1
+	case ipB == nil:
+		return -1
+	}
+	a4, b4 := ipA.To4() != nil, ipB.To4() != nil
+	if a4 != b4 {
+		if a4 {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(string(ipA.To16()), string(ipB.To16()))
+}
+
+func stringSet(values []string, normalize func(string) string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			set[normalize(v)] = struct{}{}
+		}
+	}
+	return set
+}
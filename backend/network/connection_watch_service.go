@@ -0,0 +1,240 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	connectionWatchDefaultInterval = 1000 * time.Millisecond
+	connectionWatchMinInterval     = 200 * time.Millisecond
+	connectionEventHistorySize     = 1000
+)
+
+type trackedConnection struct {
+	conn      NetworkConnection
+	firstSeen time.Time
+	lastSeen  time.Time
+}
+
+// ConnectionWatchService polls the connection table and emits "connectionOpened",
+// "connectionClosed" and "connectionStateChanged" events for every difference, so that
+// short-lived connections between two manual refreshes are not missed.
+type ConnectionWatchService struct {
+	appCtx            context.Context
+	connectionService NetworkConnectionService
+
+	mu        sync.Mutex
+	stopWatch context.CancelFunc
+	tracked   map[string]*trackedConnection
+	events    []ConnectionEvent
+}
+
+// NewConnectionWatchService creates a new ConnectionWatchService.
+func NewConnectionWatchService(connectionService NetworkConnectionService) *ConnectionWatchService {
+	return &ConnectionWatchService{
+		connectionService: connectionService,
+		tracked:           make(map[string]*trackedConnection),
+	}
+}
+
+// WailsInit stores the application context used to emit connection events.
+func (s *ConnectionWatchService) WailsInit(ctx context.Context) {
+	s.appCtx = ctx
+}
+
+// StartConnectionWatch starts polling the connection table every intervalMs milliseconds
+// (0 uses the default). The first snapshot is the baseline and emits no events.
+func (s *ConnectionWatchService) StartConnectionWatch(intervalMs int) error {
+	interval := connectionWatchDefaultInterval
+	if intervalMs > 0 {
+		interval = time.Duration(intervalMs) * time.Millisecond
+	}
+	if interval < connectionWatchMinInterval {
+		return fmt.Errorf("interval must be at least %d ms", connectionWatchMinInterval.Milliseconds())
+	}
+
+	conns, err := s.connectionService.GetConnections()
+	if err != nil {
+		return fmt.Errorf("failed to get network connections: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopWatch != nil {
+		return fmt.Errorf("connection watch is already running")
+	}
+
+	now := time.Now()
+	s.tracked = make(map[string]*trackedConnection, len(conns))
+	for key, conn := range IndexConnections(conns) {
+		s.tracked[key] = &trackedConnection{conn: conn, firstSeen: now, lastSeen: now}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stopWatch = cancel
+	go s.watchLoop(ctx, interval)
+	log.Printf("Connection watch started (interval %s, %d connections).", interval, len(conns))
+	return nil
+}
+
+// StopConnectionWatch stops polling. Tracked connections are kept until the next start.
+func (s *ConnectionWatchService) StopConnectionWatch() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopWatch != nil {
+		s.stopWatch()
+		s.stopWatch = nil
+		log.Println("Connection watch stopped.")
+	}
+}
+
+// IsConnectionWatchRunning reports whether the watch is active.
+func (s *ConnectionWatchService) IsConnectionWatchRunning() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stopWatch != nil
+}
+
+// GetWatchedConnections returns the currently open connections with their observed lifetime.
+func (s *ConnectionWatchService) GetWatchedConnections() []WatchedConnection {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	watched := make([]WatchedConnection, 0, len(s.tracked))
+	for _, t := range s.tracked {
+		watched = append(watched, WatchedConnection{
+			Connection: t.conn,
+			FirstSeen:  t.firstSeen.Format(time.RFC3339Nano),
+			LifetimeMs: now.Sub(t.firstSeen).Milliseconds(),
+		})
+	}
+	return watched
+}
+
+// GetConnectionEvents returns the recorded connection events, newest first.
+func (s *ConnectionWatchService) GetConnectionEvents() []ConnectionEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events := make([]ConnectionEvent, len(s.events))
+	for i, event := range s.events {
+		events[len(s.events)-1-i] = event
+	}
+	return events
+}
+
+// ClearConnectionEvents discards the recorded connection events.
+func (s *ConnectionWatchService) ClearConnectionEvents() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = nil
+}
+
+func (s *ConnectionWatchService) watchLoop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			conns, err := s.connectionService.GetConnections()
+			if err != nil {
+				log.Printf("WARN: Connection watch could not read connections: %v", err)
+				continue
+			}
+			s.applySnapshot(ctx, conns, time.Now())
+		}
+	}
+}
+
+// applySnapshot diffs the snapshot against the tracked connections and emits the events.
+// Snapshots of a watch that was stopped meanwhile are discarded.
+func (s *ConnectionWatchService) applySnapshot(ctx context.Context, conns []NetworkConnection, now time.Time) {
+	current := IndexConnections(conns)
+
+	s.mu.Lock()
+	if ctx.Err() != nil {
+		s.mu.Unlock()
+		return // Stopped while reading the connection table
+	}
+	previous := make(map[string]NetworkConnection, len(s.tracked))
+	for key, t := range s.tracked {
+		previous[key] = t.conn
+	}
+	diff := DiffConnections(previous, current)
+
+	var events []ConnectionEvent
+	for _, conn := range diff.Closed {
+		key := ConnectionKey(conn)
+		t := s.tracked[key]
+		delete(s.tracked, key)
+		events = append(events, newConnectionEvent("closed", t, "", now))
+	}
+	for _, change := range diff.StateChanged {
+		t := s.tracked[ConnectionKey(change.Connection)]
+		t.conn = change.Connection
+		events = append(events, newConnectionEvent("stateChanged", t, change.PreviousStatus, now))
+	}
+	for _, conn := range diff.Opened {
+		t := &trackedConnection{conn: conn, firstSeen: now}
+		s.tracked[ConnectionKey(conn)] = t
+		events = append(events, newConnectionEvent("opened", t, "", now))
+	}
+	for key, conn := range current {
+		t := s.tracked[key]
+		t.conn = conn
+		t.lastSeen = now
+	}
+
+	s.events = append(s.events, events...)
+	if overflow := len(s.events) - connectionEventHistorySize; overflow > 0 {
+		s.events = append([]ConnectionEvent(nil), s.events[overflow:]...)
+	}
+	s.mu.Unlock()
+
+	if s.appCtx == nil {
+		return
+	}
+	rdns := GetReverseDNSService()
+	for _, event := range events {
+		event.Connection.RemoteHostname = rdns.Hostname(event.Connection.RemoteIP)
+		runtime.EventsEmit(s.appCtx, connectionEventName(event.Type), event)
+	}
+}
+
+// newConnectionEvent builds an event for a tracked connection. Closed connections keep the
+// process information of the last snapshot they were seen in.
+func newConnectionEvent(eventType string, t *trackedConnection, previousStatus string, now time.Time) ConnectionEvent {
+	lastSeen := t.lastSeen
+	if eventType != "closed" {
+		lastSeen = now
+	}
+	return ConnectionEvent{
+		Type:           eventType,
+		Connection:     t.conn,
+		PreviousStatus: previousStatus,
+		FirstSeen:      t.firstSeen.Format(time.RFC3339Nano),
+		LastSeen:       lastSeen.Format(time.RFC3339Nano),
+		LifetimeMs:     lastSeen.Sub(t.firstSeen).Milliseconds(),
+		Timestamp:      now.Format(time.RFC3339Nano),
+	}
+}
+
+func connectionEventName(eventType string) string {
+	switch eventType {
+	case "opened":
+		return "connectionOpened"
+	case "closed":
+		return "connectionClosed"
+	}
+	return "connectionStateChanged"
+}
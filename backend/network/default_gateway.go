@@ -0,0 +1,109 @@
+package network
+
+import "sort"
+
+// primaryRouteProbes are public addresses whose route lookup yields the route that carries
+// internet traffic of each family. No packets are sent to them.
+var primaryRouteProbes = map[string]string{
+	"IPv4": "8.8.8.8",
+	"IPv6": "2001:4860:4860::8888",
+}
+
+// DefaultGateways derives the default gateways of both families from the routing table
+// and resolves their MAC addresses from the neighbor table. Multipath default routes
+// yield one entry per next hop.
+//
+// primaryRoutes holds the result of a route lookup for a public address per family and
+// decides which gateway is primary, which also covers policy routing, e.g., VPNs with their
+// own routing table. If it is nil, the lowest metric in the main table is primary.
+// vendorOf returns the vendor of a MAC address.
+func DefaultGateways(routes []Route, neighbors []ARPEntry, primaryRoutes map[string]Route, vendorOf func(mac string) string) []DefaultGateway {
+	var gateways []DefaultGateway
+	for _, route := range routes {
+		if !route.IsDefault {
+			continue
+		}
+		gateway := DefaultGateway{
+			Family:    route.Family,
+			Gateway:   route.Gateway,
+			Interface: route.Interface,
+			Metric:    route.Metric,
+			Table:     route.Table,
+		}
+		if len(route.Nexthops) == 0 {
+			gateways = append(gateways, gateway)
+			continue
+		}
+		for _, nexthop := range route.Nexthops {
+			gateway.Gateway = nexthop.Gateway
+			gateway.Interface = nexthop.Interface
+			gateways = append(gateways, gateway)
+		}
+	}
+
+	for i := range gateways {
+		gateways[i].MACAddress = neighborMAC(neighbors, gateways[i].Gateway, gateways[i].Interface)
+		if gateways[i].MACAddress != "" && vendorOf != nil {
+			gateways[i].Vendor = vendorOf(gateways[i].MACAddress)
+		}
+	}
+
+	sort.SliceStable(gateways, func(i, j int) bool {
+		if gateways[i].Family != gateways[j].Family {
+			return gateways[i].Family < gateways[j].Family
+		}
+		return gateways[i].Metric < gateways[j].Metric
+	})
+	primary := make(map[string]bool)
+	for i := range gateways {
+		if primary[gateways[i].Family] {
+			continue
+		}
+		isPrimary := gateways[i].Table == "main"
+		if primaryRoutes != nil {
+			route, ok := primaryRoutes[gateways[i].Family]
+			isPrimary = ok && routeUsesGateway(route, gateways[i])
+		}
+		if isPrimary {
+			gateways[i].IsPrimary = true
+			primary[gateways[i].Family] = true
+		}
+	}
+	return gateways
+}
+
+// routeUsesGateway reports whether a looked up route leaves through the gateway. Routes via
+// other next hops, e.g., the 0.0.0.0/1 and 128.0.0.0/1 routes of some VPN clients, match no
+// default gateway.
+func routeUsesGateway(route Route, gateway DefaultGateway) bool {
+	if route.Family != gateway.Family || route.Table != gateway.Table {
+		return false
+	}
+	if len(route.Nexthops) == 0 {
+		return route.Interface == gateway.Interface && route.Gateway == gateway.Gateway
+	}
+	for _, nexthop := range route.Nexthops {
+		if nexthop.Interface == gateway.Interface && nexthop.Gateway == gateway.Gateway {
+			return true
+		}
+	}
+	return false
+}
+
+// neighborMAC returns the MAC address of a resolved neighbor. The interface has to match
+// as well because IPv6 gateways are usually link-local addresses.
+func neighborMAC(neighbors []ARPEntry, ip string, iface string) string {
+	if ip == "" {
+		return ""
+	}
+	for _, neighbor := range neighbors {
+		if neighbor.IPAddress != ip || (iface != "" && neighbor.Interface != iface) {
+			continue
+		}
+		if neighbor.State == "INCOMPLETE" || neighbor.State == "FAILED" || neighbor.MACAddress == "" {
+			continue
+		}
+		return neighbor.MACAddress
+	}
+	return ""
+}
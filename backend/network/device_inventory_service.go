@@ -0,0 +1,341 @@
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	deviceInventoryFileName  = "devices.json"
+	deviceInventorySaveDelay = 10 * time.Second // Coalesces the writes of frequent observations
+	deviceIPHistoryLimit     = 20
+	onLinkNetworksTTL        = 1 * time.Minute
+)
+
+// Singleton instance (thread-safe)
+var (
+	deviceInventoryInstance *DeviceInventoryService
+	deviceInventoryOnce     sync.Once
+)
+
+// DeviceInventoryService keeps a persistent record of every LAN device seen through
+// ARP entries, ARP scans and packet captures. New devices are announced with a
+// "newDeviceSeen" event.
+type DeviceInventoryService struct {
+	appCtx context.Context
+
+	mu        sync.Mutex
+	devices   map[string]*KnownDevice
+	saveTimer *time.Timer
+
+	networksMu      sync.Mutex
+	onLinkNetworks  []*net.IPNet
+	networksUpdated time.Time
+}
+
+// GetDeviceInventoryService returns the shared DeviceInventoryService instance.
+func GetDeviceInventoryService() *DeviceInventoryService {
+	deviceInventoryOnce.Do(func() {
+		deviceInventoryInstance = &DeviceInventoryService{devices: make(map[string]*KnownDevice)}
+		if err := deviceInventoryInstance.load(); err != nil {
+			log.Printf("WARN: Could not load device inventory: %v", err)
+		}
+	})
+	return deviceInventoryInstance
+}
+
+// WailsInit stores the application context used to emit inventory events.
+func (s *DeviceInventoryService) WailsInit(ctx context.Context) {
+	s.appCtx = ctx
+}
+
+// ListDevices returns all known devices, most recently seen first.
+func (s *DeviceInventoryService) ListDevices() []KnownDevice {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	devices := make([]KnownDevice, 0, len(s.devices))
+	for _, device := range s.devices {
+		devices = append(devices, copyKnownDevice(device))
+	}
+	sort.Slice(devices, func(i, j int) bool {
+		if devices[i].LastSeen != devices[j].LastSeen {
+			return devices[i].LastSeen > devices[j].LastSeen
+		}
+		return devices[i].MACAddress < devices[j].MACAddress
+	})
+	return devices
+}
+
+// SetDeviceLabel assigns a user-defined label to a device. An empty label removes it.
+func (s *DeviceInventoryService) SetDeviceLabel(macAddress string, label string) error {
+	mac, ok := normalizeDeviceMAC(macAddress)
+	if !ok {
+		return fmt.Errorf("invalid MAC address '%s'", macAddress)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	device, ok := s.devices[mac]
+	if !ok {
+		return fmt.Errorf("unknown device %s", mac)
+	}
+	device.Label = strings.TrimSpace(label)
+	return s.saveLocked()
+}
+
+// ForgetDevice removes a device from the inventory. If it shows up again it is reported as new.
+func (s *DeviceInventoryService) ForgetDevice(macAddress string) error {
+	mac, ok := normalizeDeviceMAC(macAddress)
+	if !ok {
+		return fmt.Errorf("invalid MAC address '%s'", macAddress)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.devices[mac]; !ok {
+		return fmt.Errorf("unknown device %s", mac)
+	}
+	delete(s.devices, mac)
+	return s.saveLocked()
+}
+
+// ObserveDevice records that a MAC/IP pair was seen by the given source, e.g. "arp-cache".
+// It reports whether the device was not known before.
+func (s *DeviceInventoryService) ObserveDevice(macAddress string, ipAddress string, source string) bool {
+	mac, ok := normalizeDeviceMAC(macAddress)
+	if !ok {
+		return false
+	}
+	ip := net.ParseIP(ipAddress)
+	if ip == nil || ip.IsUnspecified() || ip.IsMulticast() || ip.IsLoopback() {
+		return false
+	}
+	ipAddress = ip.String()
+	now := time.Now().Format(time.RFC3339)
+
+	s.mu.Lock()
+	device, known := s.devices[mac]
+	if !known {
+		vendor := GetVendorService().LookupVendor(mac)
+		device = &KnownDevice{
+			MACAddress:          mac,
+			Vendor:              vendor.Vendor,
+			LocallyAdministered: vendor.LocallyAdministered,
+			FirstSeen:           now,
+		}
+		s.devices[mac] = device
+	}
+	device.LastSeen = now
+	device.LastIPAddress = ipAddress
+	if !containsSource(device.Sources, source) {
+		device.Sources = append(device.Sources, source)
+	}
+	recordDeviceIP(device, ipAddress, now)
+	s.scheduleSaveLocked()
+	var snapshot KnownDevice
+	if !known {
+		snapshot = copyKnownDevice(device)
+	}
+	s.mu.Unlock()
+
+	if !known {
+		log.Printf("New device seen: %s (%s) via %s", mac, ipAddress, source)
+		if s.appCtx != nil {
+			runtime.EventsEmit(s.appCtx, "newDeviceSeen", snapshot)
+		}
+	}
+	return !known
+}
+
+// ObserveCapturedFrame records the source of a captured frame. The IP address is only
+// attributed to the MAC address if it belongs to a directly connected network; otherwise
+// the MAC address is the one of the router that forwarded the packet.
+func (s *DeviceInventoryService) ObserveCapturedFrame(sourceMAC string, sourceIP string) bool {
+	ip := net.ParseIP(sourceIP)
+	if ip == nil || !s.isOnLink(ip) {
+		return false
+	}
+	return s.ObserveDevice(sourceMAC, sourceIP, "capture")
+}
+
+// isOnLink reports whether the IP belongs to a subnet of a local interface or is link-local.
+// Addresses of this host are not on-link in this sense.
+func (s *DeviceInventoryService) isOnLink(ip net.IP) bool {
+	s.networksMu.Lock()
+	defer s.networksMu.Unlock()
+	if time.Since(s.networksUpdated) > onLinkNetworksTTL {
+		s.onLinkNetworks = nil
+		if addrs, err := net.InterfaceAddrs(); err == nil {
+			for _, addr := range addrs {
+				if ipnet, ok := addr.(*net.IPNet); ok && !ipnet.IP.IsLoopback() {
+					s.onLinkNetworks = append(s.onLinkNetworks, ipnet)
+				}
+			}
+		}
+		s.networksUpdated = time.Now()
+	}
+	for _, network := range s.onLinkNetworks {
+		if network.IP.Equal(ip) {
+			return false
+		}
+	}
+	if ip.IsLinkLocalUnicast() {
+		return true
+	}
+	for _, network := range s.onLinkNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// Shutdown writes a pending inventory save before the application exits.
+func (s *DeviceInventoryService) Shutdown() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.saveTimer == nil {
+		return
+	}
+	if err := s.saveLocked(); err != nil {
+		log.Printf("WARN: Could not save device inventory: %v", err)
+	}
+}
+
+// scheduleSaveLocked writes the inventory after deviceInventorySaveDelay unless a write is already pending.
+func (s *DeviceInventoryService) scheduleSaveLocked() {
+	if s.saveTimer != nil {
+		return
+	}
+	s.saveTimer = time.AfterFunc(deviceInventorySaveDelay, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if err := s.saveLocked(); err != nil {
+			log.Printf("WARN: Could not save device inventory: %v", err)
+		}
+	})
+}
+
+// saveLocked writes the inventory to the config directory. The caller must hold s.mu.
+func (s *DeviceInventoryService) saveLocked() error {
+	if s.saveTimer != nil {
+		s.saveTimer.Stop()
+		s.saveTimer = nil
+	}
+
+	filePath, err := deviceInventoryFilePath()
+	if err != nil {
+		return err
+	}
+	devices := make([]*KnownDevice, 0, len(s.devices))
+	for _, device := range s.devices {
+		devices = append(devices, device)
+	}
+	sort.Slice(devices, func(i, j int) bool { return devices[i].MACAddress < devices[j].MACAddress })
+
+	data, err := json.MarshalIndent(devices, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal device inventory: %w", err)
+	}
+	tmpPath := filePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write device inventory: %w", err)
+	}
+	if err := os.Rename(tmpPath, filePath); err != nil {
+		return fmt.Errorf("failed to replace device inventory: %w", err)
+	}
+	return nil
+}
+
+// load reads the inventory written by saveLocked.
+func (s *DeviceInventoryService) load() error {
+	filePath, err := deviceInventoryFilePath()
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read device inventory: %w", err)
+	}
+
+	var devices []*KnownDevice
+	if err := json.Unmarshal(data, &devices); err != nil {
+		return fmt.Errorf("failed to unmarshal device inventory: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, device := range devices {
+		if mac, ok := normalizeDeviceMAC(device.MACAddress); ok {
+			device.MACAddress = mac
+			s.devices[mac] = device
+		}
+	}
+	return nil
+}
+
+func deviceInventoryFilePath() (string, error) {
+	dir, err := appConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, deviceInventoryFileName), nil
+}
+
+// recordDeviceIP updates the IP history of a device, dropping the oldest entries beyond the limit.
+func recordDeviceIP(device *KnownDevice, ipAddress string, now string) {
+	for i := range device.IPHistory {
+		if device.IPHistory[i].IPAddress == ipAddress {
+			device.IPHistory[i].LastSeen = now
+			return
+		}
+	}
+	device.IPHistory = append(device.IPHistory, DeviceIPRecord{IPAddress: ipAddress, FirstSeen: now, LastSeen: now})
+	if len(device.IPHistory) > deviceIPHistoryLimit {
+		device.IPHistory = device.IPHistory[len(device.IPHistory)-deviceIPHistoryLimit:]
+	}
+}
+
+// copyKnownDevice returns a copy that does not share slices with the inventory.
+func copyKnownDevice(device *KnownDevice) KnownDevice {
+	c := *device
+	c.IPHistory = append([]DeviceIPRecord(nil), device.IPHistory...)
+	c.Sources = append([]string(nil), device.Sources...)
+	return c
+}
+
+// normalizeDeviceMAC returns the canonical form of a unicast Ethernet MAC address.
+func normalizeDeviceMAC(macAddress string) (string, bool) {
+	mac, err := net.ParseMAC(macAddress)
+	if err != nil || len(mac) != 6 || mac[0]&0x01 != 0 {
+		return "", false // Invalid, non-Ethernet, multicast or broadcast
+	}
+	if mac.String() == "00:00:00:00:00:00" {
+		return "", false
+	}
+	return mac.String(), true
+}
+
+func containsSource(sources []string, source string) bool {
+	for _, s := range sources {
+		if s == source {
+			return true
+		}
+	}
+	return false
+}
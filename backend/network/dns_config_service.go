@@ -0,0 +1,6 @@
+package network
+
+// DNSConfigService defines the interface for inspecting the DNS resolver configuration.
+type DNSConfigService interface {
+	GetDNSConfig() (*DNSConfig, error)
+}
@@ -0,0 +1,243 @@
+package network
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"time"
+)
+
+// Bind scopes of a listening socket.
+const (
+	ScopeLoopback      = "loopback"       // Reachable from this host only
+	ScopeLAN           = "lan"            // Bound to a private or link-local address
+	ScopeAllInterfaces = "all-interfaces" // Bound to 0.0.0.0
+	ScopeIPv6Any       = "ipv6-any"       // Bound to ::, often dual-stack
+	ScopePublic        = "public"         // Bound to a globally routable address
+)
+
+// Risk levels of an exposed service, highest first.
+const (
+	RiskHigh   = "high"
+	RiskMedium = "medium"
+	RiskLow    = "low"
+	RiskInfo   = "info"
+)
+
+type knownService struct {
+	name     string
+	category string
+}
+
+// riskyServices lists ports of services that should not be reachable from other hosts.
+var riskyServices = map[string]knownService{
+	"tcp/1433":  {"Microsoft SQL Server", "database"},
+	"tcp/1521":  {"Oracle Database", "database"},
+	"tcp/3306":  {"MySQL/MariaDB", "database"},
+	"tcp/5432":  {"PostgreSQL", "database"},
+	"tcp/5984":  {"CouchDB", "database"},
+	"tcp/6379":  {"Redis", "database"},
+	"tcp/8086":  {"InfluxDB", "database"},
+	"tcp/9042":  {"Cassandra", "database"},
+	"tcp/9200":  {"Elasticsearch", "database"},
+	"tcp/11211": {"Memcached", "database"},
+	"udp/11211": {"Memcached", "database"},
+	"tcp/27017": {"MongoDB", "database"},
+
+	"tcp/23":   {"Telnet", "remote-desktop"},
+	"tcp/3389": {"Remote Desktop (RDP)", "remote-desktop"},
+	"udp/3389": {"Remote Desktop (RDP)", "remote-desktop"},
+	"tcp/5900": {"VNC", "remote-desktop"},
+	"tcp/5901": {"VNC", "remote-desktop"},
+	"tcp/5902": {"VNC", "remote-desktop"},
+	"tcp/5903": {"VNC", "remote-desktop"},
+
+	"tcp/139": {"NetBIOS Session (SMB)", "file-sharing"},
+	"tcp/445": {"SMB", "file-sharing"},
+	"udp/137": {"NetBIOS Name Service", "file-sharing"},
+	"udp/138": {"NetBIOS Datagram", "file-sharing"},
+
+	"tcp/2375": {"Docker API (unencrypted)", "debug"},
+	"tcp/5005": {"Java Debug Wire Protocol", "debug"},
+	"tcp/5037": {"Android Debug Bridge", "debug"},
+	"tcp/5678": {"Python debugpy", "debug"},
+	"tcp/9222": {"Chrome DevTools", "debug"},
+	"tcp/9229": {"Node.js Inspector", "debug"},
+}
+
+// commonServices names other well-known ports that are reported without extra risk.
+var commonServices = map[string]string{
+	"tcp/21":   "FTP",
+	"tcp/22":   "SSH",
+	"tcp/25":   "SMTP",
+	"tcp/53":   "DNS",
+	"udp/53":   "DNS",
+	"udp/67":   "DHCP",
+	"udp/68":   "DHCP",
+	"tcp/80":   "HTTP",
+	"udp/123":  "NTP",
+	"tcp/443":  "HTTPS",
+	"udp/443":  "QUIC",
+	"tcp/631":  "IPP (printing)",
+	"udp/1900": "SSDP/UPnP",
+	"udp/5353": "mDNS",
+	"udp/5355": "LLMNR",
+	"tcp/8080": "HTTP (alternate)",
+}
+
+// ExposureAuditService lists every listening TCP socket and UDP bind and classifies how
+// far it is reachable and how risky the service behind it is.
+type ExposureAuditService struct {
+	connectionService NetworkConnectionService
+}
+
+// NewExposureAuditService creates a new ExposureAuditService.
+func NewExposureAuditService(connectionService NetworkConnectionService) *ExposureAuditService {
+	return &ExposureAuditService{connectionService: connectionService}
+}
+
+// AuditListeningPorts runs the exposure audit on the current socket table.
+func (s *ExposureAuditService) AuditListeningPorts() (*ExposureAudit, error) {
+	conns, err := s.connectionService.GetConnections()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network connections: %w", err)
+	}
+
+	audit := &ExposureAudit{GeneratedAt: time.Now().Format(time.RFC3339)}
+	processes := GetProcessContextService()
+	executables := make(map[int32]string)
+	seen := make(map[string]struct{})
+	for _, conn := range conns {
+		protocol, ok := listeningProtocol(conn)
+		if !ok {
+			continue
+		}
+		key := fmt.Sprintf("%s/%s/%d/%d", protocol, conn.LocalIP, conn.LocalPort, conn.PID)
+		if _, dup := seen[key]; dup {
+			continue // e.g., SO_REUSEPORT listeners of the same process
+		}
+		seen[key] = struct{}{}
+
+		service := classifyExposedService(protocol, conn)
+		if conn.PID != 0 {
+			exe, cached := executables[conn.PID]
+			if !cached {
+				if ctx := processes.Lookup(conn.PID); ctx != nil {
+					exe = ctx.ExecutablePath
+				}
+				executables[conn.PID] = exe
+			}
+			service.ExecutablePath = exe
+		}
+
+		switch service.Risk {
+		case RiskHigh:
+			audit.HighRisk++
+		case RiskMedium:
+			audit.MediumRisk++
+		}
+		if service.Scope != ScopeLoopback {
+			audit.Exposed++
+		}
+		audit.Services = append(audit.Services, service)
+	}
+
+	sort.Slice(audit.Services, func(i, j int) bool {
+		a, b := audit.Services[i], audit.Services[j]
+		if riskRank(a.Risk) != riskRank(b.Risk) {
+			return riskRank(a.Risk) < riskRank(b.Risk)
+		}
+		if a.LocalPort != b.LocalPort {
+			return a.LocalPort < b.LocalPort
+		}
+		return a.Protocol < b.Protocol
+	})
+	return audit, nil
+}
+
+// listeningProtocol returns the protocol of TCP sockets in LISTEN state and unconnected UDP binds.
+func listeningProtocol(conn NetworkConnection) (string, bool) {
+	protocol := ConnectionProtocol(conn)
+	switch protocol {
+	case "tcp":
+		return protocol, conn.Status == "LISTEN"
+	case "udp":
+		return protocol, conn.RemotePort == 0 && conn.LocalPort != 0
+	}
+	return "", false
+}
+
+// classifyExposedService determines scope, known service and risk of a listening socket.
+func classifyExposedService(protocol string, conn NetworkConnection) ExposedService {
+	service := ExposedService{
+		Protocol:    protocol,
+		FamilyName:  conn.FamilyName,
+		LocalIP:     conn.LocalIP,
+		LocalPort:   conn.LocalPort,
+		Scope:       bindScope(conn.LocalIP),
+		PID:         conn.PID,
+		ProcessName: conn.ProcessName,
+		Risk:        RiskInfo,
+	}
+	portKey := fmt.Sprintf("%s/%d", protocol, conn.LocalPort)
+
+	if known, ok := riskyServices[portKey]; ok {
+		service.ServiceName = known.name
+		service.Category = known.category
+		switch service.Scope {
+		case ScopeLoopback:
+			service.Risk = RiskLow
+		case ScopeLAN:
+			service.Risk = RiskMedium
+			service.Reasons = append(service.Reasons, fmt.Sprintf("%s is reachable from the local network", known.name))
+		default:
+			service.Risk = RiskHigh
+			service.Reasons = append(service.Reasons, fmt.Sprintf("%s is reachable from other hosts (%s)", known.name, service.Scope))
+		}
+		return service
+	}
+
+	service.ServiceName = commonServices[portKey]
+	switch service.Scope {
+	case ScopeAllInterfaces, ScopeIPv6Any, ScopePublic:
+		service.Risk = RiskMedium
+		service.Reasons = append(service.Reasons, "Listening on all interfaces; reachable from other hosts unless blocked by a firewall")
+	case ScopeLAN:
+		service.Risk = RiskLow
+		service.Reasons = append(service.Reasons, "Reachable from the local network")
+	}
+	if service.Scope == ScopePublic {
+		service.Reasons = append(service.Reasons, "Bound to a globally routable address")
+	}
+	return service
+}
+
+// bindScope classifies the local address a socket is bound to.
+func bindScope(localIP string) string {
+	ip := net.ParseIP(localIP)
+	switch {
+	case ip == nil:
+		return ScopeAllInterfaces
+	case ip.IsLoopback():
+		return ScopeLoopback
+	case ip.IsUnspecified() && ip.To4() == nil:
+		return ScopeIPv6Any
+	case ip.IsUnspecified():
+		return ScopeAllInterfaces
+	case ip.IsPrivate() || ip.IsLinkLocalUnicast():
+		return ScopeLAN
+	}
+	return ScopePublic
+}
+
+func riskRank(risk string) int {
+	switch risk {
+	case RiskHigh:
+		return 0
+	case RiskMedium:
+		return 1
+	case RiskLow:
+		return 2
+	}
+	return 3
+}
@@ -51,6 +51,25 @@ func getDefaultInterfaceNameDarwin() (string, error) {
 	return "", fmt.Errorf("default network interface not found")
 }
 
+// getDefaultGatewayIP reads the IPv4 gateway of the default route from "route -n get default".
+func getDefaultGatewayIP() (string, error) {
+	out, err := exec.Command("route", "-n", "get", "default").Output()
+	if err != nil {
+		return "", err
+	}
+
+	for _, line := range strings.Split(string(out), "\n") {
+		if strings.Contains(line, "gateway:") {
+			fields := strings.Fields(line)
+			if len(fields) >= 2 {
+				return fields[1], nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("default gateway not found")
+}
+
 func fetchPublicIP() (string, error) {
 	resp, err := http.Get("https://api.ipify.org")
 	if err != nil {
@@ -9,6 +9,7 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 )
 
@@ -48,6 +49,31 @@ func getDefaultInterfaceNameLinux() (string, error) {
 	return "", fmt.Errorf("default network interface not found")
 }
 
+// getDefaultGatewayIP reads the IPv4 gateway of the default route from /proc/net/route.
+func getDefaultGatewayIP() (string, error) {
+	file, err := os.Open("/proc/net/route")
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || fields[1] != "00000000" {
+			continue
+		}
+		// The gateway is stored as little-endian hex, e.g., "0101A8C0" for 192.168.1.1
+		gateway, err := strconv.ParseUint(fields[2], 16, 32)
+		if err != nil || gateway == 0 {
+			continue
+		}
+		return net.IPv4(byte(gateway), byte(gateway>>8), byte(gateway>>16), byte(gateway>>24)).String(), nil
+	}
+
+	return "", fmt.Errorf("default gateway not found")
+}
+
 func fetchPublicIP() (string, error) {
 	resp, err := http.Get("https://api.ipify.org")
 	if err != nil {
@@ -7,3 +7,7 @@ import "errors"
 func getPublicIPInfo() (*PublicIPInfo, error) {
 	return nil, errors.New("default interface lookup not supported on this platform")
 }
+
+func getDefaultGatewayIP() (string, error) {
+	return "", errors.New("default gateway lookup not supported on this platform")
+}
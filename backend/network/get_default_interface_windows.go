@@ -47,6 +47,28 @@ func getPublicIPInfo() (*PublicIPInfo, error) {
 }
 
 func getDefaultInterfaceNameWindows() (string, error) {
+	row, err := getDefaultRouteWindows()
+	if err != nil {
+		return "", err
+	}
+	iface, err := net.InterfaceByIndex(int(row.ForwardIfIndex))
+	if err != nil {
+		return "", err
+	}
+	return iface.Name, nil
+}
+
+// getDefaultGatewayIP returns the next hop of the default route.
+func getDefaultGatewayIP() (string, error) {
+	row, err := getDefaultRouteWindows()
+	if err != nil {
+		return "", err
+	}
+	return net.IP(row.ForwardNextHop[:]).String(), nil
+}
+
+// getDefaultRouteWindows returns the first default route whose interface still exists.
+func getDefaultRouteWindows() (*MIB_IPFORWARDROW, error) {
 	iphlpapi := syscall.NewLazyDLL("iphlpapi.dll")
 	getIpForwardTable := iphlpapi.NewProc("GetIpForwardTable")
 
@@ -56,7 +78,7 @@ func getDefaultInterfaceNameWindows() (string, error) {
 	// Get buffer size
 	ret, _, _ := getIpForwardTable.Call(uintptr(unsafe.Pointer(nil)), uintptr(unsafe.Pointer(&bufferSize)), 0)
 	if syscall.Errno(ret) != syscall.ERROR_INSUFFICIENT_BUFFER {
-		return "", fmt.Errorf("GetIpForwardTable failed to get buffer size: %v", syscall.Errno(ret))
+		return nil, fmt.Errorf("GetIpForwardTable failed to get buffer size: %v", syscall.Errno(ret))
 	}
 
 	buffer = make([]byte, bufferSize)
@@ -64,25 +86,25 @@ func getDefaultInterfaceNameWindows() (string, error) {
 	// Get the table
 	ret, _, _ = getIpForwardTable.Call(uintptr(unsafe.Pointer(&buffer[0])), uintptr(unsafe.Pointer(&bufferSize)), 0)
 	if syscall.Errno(ret) != 0 { // 0 means NO_ERROR
-		return "", fmt.Errorf("GetIpForwardTable failed: %v", syscall.Errno(ret))
+		return nil, fmt.Errorf("GetIpForwardTable failed: %v", syscall.Errno(ret))
 	}
 
 	// First 4 bytes are the number of entries
 	numEntries := *(*uint32)(unsafe.Pointer(&buffer[0]))
 	rows := (*[1 << 20]MIB_IPFORWARDROW)(unsafe.Pointer(&buffer[4]))[:numEntries]
 
-	for _, row := range rows {
+	for i := range rows {
 		// Default route has a destination of 0.0.0.0
-		if row.ForwardDest == [4]byte{0, 0, 0, 0} {
-			iface, err := net.InterfaceByIndex(int(row.ForwardIfIndex))
-			if err != nil {
+		if rows[i].ForwardDest == [4]byte{0, 0, 0, 0} {
+			if _, err := net.InterfaceByIndex(int(rows[i].ForwardIfIndex)); err != nil {
 				continue // Try next default route if this one fails
 			}
-			return iface.Name, nil
+			row := rows[i]
+			return &row, nil
 		}
 	}
 
-	return "", fmt.Errorf("default network interface not found")
+	return nil, fmt.Errorf("default network interface not found")
 }
 
 func fetchPublicIP() (string, error) {
@@ -0,0 +1,58 @@
+package network
+
+import (
+	"net"
+	"strings"
+)
+
+// InterfaceAddressesFromCIDRs derives structured addresses from the CIDR strings of
+// NetworkInterface.Addrs for platforms that report no scope, flags or lifetimes.
+func InterfaceAddressesFromCIDRs(addrs []string) []InterfaceAddress {
+	var addresses []InterfaceAddress
+	for _, addr := range addrs {
+		ip, ipNet, err := net.ParseCIDR(addr)
+		if err != nil {
+			if ip = net.ParseIP(strings.SplitN(addr, "%", 2)[0]); ip == nil {
+				continue
+			}
+			bits := net.IPv6len * 8
+			if ip.To4() != nil {
+				bits = net.IPv4len * 8
+			}
+			ipNet = &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}
+		}
+
+		prefixLength, bits := ipNet.Mask.Size()
+		address := InterfaceAddress{
+			IP:           ip.String(),
+			PrefixLength: prefixLength,
+			Family:       "IPv6",
+			Scope:        AddressScope(ip),
+		}
+		if ip4 := ip.To4(); ip4 != nil {
+			address.Family = "IPv4"
+			// /31 and /32 networks have no broadcast address (RFC 3021)
+			if bits-prefixLength > 1 && !ip4.IsLoopback() {
+				broadcast := make(net.IP, net.IPv4len)
+				for i := range broadcast {
+					broadcast[i] = ip4[i] | ^ipNet.Mask[len(ipNet.Mask)-net.IPv4len+i]
+				}
+				address.Broadcast = broadcast.String()
+			}
+		}
+		addresses = append(addresses, address)
+	}
+	return addresses
+}
+
+// AddressScope classifies an IP address like the kernel's address scopes: "host" for
+// loopback, "link" for link-local and "global" for everything else.
+func AddressScope(ip net.IP) string {
+	switch {
+	case ip.IsLoopback():
+		return "host"
+	case ip.IsLinkLocalUnicast(), ip.IsLinkLocalMulticast(), ip.IsInterfaceLocalMulticast():
+		return "link"
+	}
+	return "global"
+}
@@ -0,0 +1,38 @@
+package network
+
+import (
+	"fmt"
+
+	gopsnet "github.com/shirou/gopsutil/v3/net"
+)
+
+// InterfaceCounterService defines the interface for reading traffic counters per interface name.
+type InterfaceCounterService interface {
+	GetInterfaceCounters() (map[string]InterfaceCounters, error)
+}
+
+// GopsutilInterfaceCounterService reads interface counters with gopsutil. It is used on
+// platforms without a native implementation and as fallback on Linux.
+type GopsutilInterfaceCounterService struct{}
+
+// GetInterfaceCounters returns the counters of all interfaces. Multicast is not reported.
+func (GopsutilInterfaceCounterService) GetInterfaceCounters() (map[string]InterfaceCounters, error) {
+	stats, err := gopsnet.IOCounters(true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get interface counters: %w", err)
+	}
+	counters := make(map[string]InterfaceCounters, len(stats))
+	for _, stat := range stats {
+		counters[stat.Name] = InterfaceCounters{
+			RxBytes:   stat.BytesRecv,
+			TxBytes:   stat.BytesSent,
+			RxPackets: stat.PacketsRecv,
+			TxPackets: stat.PacketsSent,
+			RxErrors:  stat.Errin,
+			TxErrors:  stat.Errout,
+			RxDropped: stat.Dropin,
+			TxDropped: stat.Dropout,
+		}
+	}
+	return counters, nil
+}
@@ -0,0 +1,243 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	interfaceSamplingDefaultInterval = time.Second
+	interfaceSamplingMinInterval     = 250 * time.Millisecond
+	interfaceRateMaxWindow           = time.Hour
+)
+
+var defaultInterfaceRateWindows = []int{1, 10, 60}
+
+type interfaceSample struct {
+	at       time.Time
+	counters map[string]InterfaceCounters
+}
+
+// InterfaceStatsService samples the interface counters periodically and computes the
+// throughput over configurable windows. Every sample is emitted as "interfaceThroughput"
+// event with one InterfaceStats entry per interface.
+type InterfaceStatsService struct {
+	appCtx         context.Context
+	counterService InterfaceCounterService
+
+	mu      sync.Mutex
+	stop    context.CancelFunc
+	windows []int
+	samples []interfaceSample // Oldest first, covering the largest window
+	latest  []InterfaceStats
+}
+
+// NewInterfaceStatsService creates a new InterfaceStatsService.
+func NewInterfaceStatsService(counterService InterfaceCounterService) *InterfaceStatsService {
+	return &InterfaceStatsService{
+		counterService: counterService,
+		windows:        defaultInterfaceRateWindows,
+	}
+}
+
+// WailsInit stores the application context used to emit throughput events.
+func (s *InterfaceStatsService) WailsInit(ctx context.Context) {
+	s.appCtx = ctx
+}
+
+// StartInterfaceSampling samples the counters every intervalMs milliseconds (0 uses the
+// default of one second) and computes rates over the given windows in seconds
+// (empty uses 1, 10 and 60 seconds).
+func (s *InterfaceStatsService) StartInterfaceSampling(intervalMs int, windowSeconds []int) error {
+	interval := interfaceSamplingDefaultInterval
+	if intervalMs > 0 {
+		interval = time.Duration(intervalMs) * time.Millisecond
+	}
+	if interval < interfaceSamplingMinInterval {
+		return fmt.Errorf("interval must be at least %d ms", interfaceSamplingMinInterval.Milliseconds())
+	}
+	windows, err := normalizeRateWindows(windowSeconds)
+	if err != nil {
+		return err
+	}
+
+	counters, err := s.counterService.GetInterfaceCounters()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil {
+		return fmt.Errorf("interface sampling is already running")
+	}
+	s.windows = windows
+	s.samples = []interfaceSample{{at: time.Now(), counters: counters}}
+	s.latest = nil
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stop = cancel
+	go s.sampleLoop(ctx, interval)
+	log.Printf("Interface sampling started (interval %s, windows %v s).", interval, windows)
+	return nil
+}
+
+// StopInterfaceSampling stops sampling. The last computed stats remain available.
+func (s *InterfaceStatsService) StopInterfaceSampling() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil {
+		s.stop()
+		s.stop = nil
+		log.Println("Interface sampling stopped.")
+	}
+}
+
+// IsInterfaceSamplingRunning reports whether the sampler is active.
+func (s *InterfaceStatsService) IsInterfaceSamplingRunning() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stop != nil
+}
+
+// GetInterfaceStats returns the counters and rates of the latest sample. If the sampler
+// has not produced a sample yet, the current counters are returned without rates.
+func (s *InterfaceStatsService) GetInterfaceStats() ([]InterfaceStats, error) {
+	s.mu.Lock()
+	latest := s.latest
+	s.mu.Unlock()
+	if latest != nil {
+		return latest, nil
+	}
+
+	counters, err := s.counterService.GetInterfaceCounters()
+	if err != nil {
+		return nil, err
+	}
+	return buildInterfaceStats(time.Now(), counters, nil, nil), nil
+}
+
+func (s *InterfaceStatsService) sampleLoop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			counters, err := s.counterService.GetInterfaceCounters()
+			if err != nil {
+				log.Printf("WARN: Interface sampling could not read counters: %v", err)
+				continue
+			}
+			stats, ok := s.addSample(ctx, time.Now(), counters)
+			if ok && s.appCtx != nil {
+				runtime.EventsEmit(s.appCtx, "interfaceThroughput", stats)
+			}
+		}
+	}
+}
+
+// addSample stores a sample, drops samples older than the largest window and computes the stats.
+// Samples of a sampler that was stopped meanwhile are discarded.
+func (s *InterfaceStatsService) addSample(ctx context.Context, now time.Time, counters map[string]InterfaceCounters) ([]InterfaceStats, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ctx.Err() != nil {
+		return nil, false // Stopped while reading the counters
+	}
+
+	s.samples = append(s.samples, interfaceSample{at: now, counters: counters})
+	maxWindow := time.Duration(s.windows[len(s.windows)-1]) * time.Second
+	keepFrom := 0
+	for keepFrom+1 < len(s.samples) && !s.samples[keepFrom+1].at.After(now.Add(-maxWindow)) {
+		keepFrom++ // Keep the newest sample at or before the window start
+	}
+	s.samples = s.samples[keepFrom:]
+
+	s.latest = buildInterfaceStats(now, counters, s.samples[:len(s.samples)-1], s.windows)
+	return s.latest, true
+}
+
+// buildInterfaceStats computes the stats of every interface. For each window the reference
+// is the newest previous sample at or before the window start, or the oldest sample if
+// the history is shorter than the window.
+func buildInterfaceStats(now time.Time, counters map[string]InterfaceCounters, previous []interfaceSample, windows []int) []InterfaceStats {
+	references := make([]*interfaceSample, len(windows))
+	for i, window := range windows {
+		start := now.Add(-time.Duration(window) * time.Second)
+		for j := range previous {
+			if previous[j].at.After(start) && references[i] != nil {
+				break
+			}
+			references[i] = &previous[j]
+		}
+	}
+
+	timestamp := now.Format(time.RFC3339Nano)
+	stats := make([]InterfaceStats, 0, len(counters))
+	for name, current := range counters {
+		entry := InterfaceStats{Name: name, Counters: current, Rates: []InterfaceRate{}, Timestamp: timestamp}
+		for i, window := range windows {
+			rate := InterfaceRate{WindowSeconds: window}
+			if ref := references[i]; ref != nil {
+				if old, ok := ref.counters[name]; ok {
+					rate = interfaceRate(window, now.Sub(ref.at), old, current)
+				}
+			}
+			entry.Rates = append(entry.Rates, rate)
+		}
+		stats = append(stats, entry)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+	return stats
+}
+
+// interfaceRate computes per-second rates between two counter readings. Counters that went
+// backwards (interface reset or wrap) yield 0.
+func interfaceRate(window int, elapsed time.Duration, old, current InterfaceCounters) InterfaceRate {
+	rate := InterfaceRate{WindowSeconds: window, ElapsedSeconds: elapsed.Seconds()}
+	if elapsed <= 0 {
+		return rate
+	}
+	perSec := func(oldValue, newValue uint64) float64 {
+		if newValue < oldValue {
+			return 0
+		}
+		return float64(newValue-oldValue) / elapsed.Seconds()
+	}
+	rate.RxBytesPerSec = perSec(old.RxBytes, current.RxBytes)
+	rate.TxBytesPerSec = perSec(old.TxBytes, current.TxBytes)
+	rate.RxPacketsPerSec = perSec(old.RxPackets, current.RxPackets)
+	rate.TxPacketsPerSec = perSec(old.TxPackets, current.TxPackets)
+	rate.ErrorsPerSec = perSec(old.RxErrors+old.TxErrors, current.RxErrors+current.TxErrors)
+	rate.DroppedPerSec = perSec(old.RxDropped+old.TxDropped, current.RxDropped+current.TxDropped)
+	return rate
+}
+
+// normalizeRateWindows validates, sorts and deduplicates the rate windows.
+func normalizeRateWindows(windowSeconds []int) ([]int, error) {
+	if len(windowSeconds) == 0 {
+		return defaultInterfaceRateWindows, nil
+	}
+	seen := make(map[int]struct{}, len(windowSeconds))
+	var windows []int
+	for _, window := range windowSeconds {
+		if window < 1 || time.Duration(window)*time.Second > interfaceRateMaxWindow {
+			return nil, fmt.Errorf("rate windows must be between 1 and %d seconds", int(interfaceRateMaxWindow.Seconds()))
+		}
+		if _, dup := seen[window]; !dup {
+			seen[window] = struct{}{}
+			windows = append(windows, window)
+		}
+	}
+	sort.Ints(windows)
+	return windows, nil
+}
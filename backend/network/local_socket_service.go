@@ -0,0 +1,8 @@
+package network
+
+// LocalSocketService defines the interface for listing sockets beyond TCP and UDP:
+// Unix domain sockets and AF_PACKET/raw sockets.
+type LocalSocketService interface {
+	GetUnixSockets() ([]UnixSocket, error)
+	GetRawSockets() ([]RawSocket, error)
+}
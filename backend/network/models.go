@@ -13,11 +13,52 @@ type NetworkInterface struct {
 	MTU         int      `json:"MTU"`
 	Flags       []string `json:"Flags"`
 	Addrs       []string `json:"Addrs"`
+	Addresses   []InterfaceAddress `json:"Addresses"` // Structured form of Addrs with scope, flags and lifetimes (from netlink on Linux)
 	IsUp        bool     `json:"IsUp"`
 	IsLoopback  bool     `json:"IsLoopback"`
 	IsBroadcast bool     `json:"IsBroadcast"`
 	IsPointToPoint bool  `json:"IsPointToPoint"`
 	IsMulticast bool     `json:"IsMulticast"`
+
+	Vendor              string `json:"Vendor"`              // From the OUI registry
+	LocallyAdministered bool   `json:"LocallyAdministered"` // Randomized or otherwise not IEEE-assigned MAC
+
+	Counters *InterfaceCounters `json:"Counters,omitempty"` // Traffic counters since the interface was brought up
+
+	Index     int      `json:"Index"`
+	Kind      string   `json:"Kind"`      // e.g., "ethernet", "wireless", "bridge", "bond", "vlan", "veth", "tun", "tap", "wireguard", "loopback", "dummy" (Linux only); "pseudo" for capture-only devices like "any" or "nflog"
+	IsVirtual bool     `json:"IsVirtual"` // Not backed by a hardware device (Linux only)
+	Origin    string   `json:"Origin"`    // Software that probably created the interface, e.g., "docker", "libvirt" (Linux only)
+	Driver    string   `json:"Driver"`
+	SpeedMbps int      `json:"SpeedMbps"` // 0 if unknown or not applicable
+	Duplex    string   `json:"Duplex"`    // "full", "half" or empty
+	OperState string   `json:"OperState"` // RFC 2863 state, e.g., "up", "down", "dormant", "unknown"
+	Master    string   `json:"Master"`    // Bridge or bond this interface is a port of
+	Slaves    []string `json:"Slaves"`    // Ports of a bridge or bond
+	Parent    string   `json:"Parent"`    // Lower device of a VLAN or macvlan, peer of a veth in the same namespace
+	VLANID    int      `json:"VLANID"`
+
+	Wireless *WirelessInfo `json:"Wireless,omitempty"` // Wi-Fi connection of wireless interfaces
+
+	Sources                  []string `json:"Sources"` // Where the interface was found: "net", "sysfs" (Linux only), "pcap"
+	HasAddresses             bool     `json:"HasAddresses"`
+	CanCapture               bool     `json:"CanCapture"`
+	CaptureName              string   `json:"CaptureName"`              // Device name for packet capture; differs from Name on Windows
+	AdapterID                string   `json:"AdapterID"`                // Adapter GUID, e.g., "{4D36E972-...}" (Windows only); Npcap names its devices after it
+	CaptureUnavailableReason string   `json:"CaptureUnavailableReason"` // Why CanCapture is false
+}
+
+// InterfaceAddress is an IP address assigned to a network interface.
+type InterfaceAddress struct {
+	IP                string   `json:"IP"`
+	PrefixLength      int      `json:"PrefixLength"`
+	Family            string   `json:"Family"`            // "IPv4" or "IPv6"
+	Scope             string   `json:"Scope"`             // "host", "link", "site" or "global"
+	Flags             []string `json:"Flags"`             // e.g., "temporary", "deprecated", "tentative", "mngtmpaddr", "dynamic" (Linux only)
+	ValidLifetime     int64    `json:"ValidLifetime"`     // Seconds, -1 for forever, 0 if unknown (Linux only)
+	PreferredLifetime int64    `json:"PreferredLifetime"` // Seconds, -1 for forever, 0 for deprecated addresses or if unknown (Linux only)
+	Broadcast         string   `json:"Broadcast"`
+	Label             string   `json:"Label"` // IPv4 address label, e.g., "eth0:1" (Linux only)
 }
 
 // CapturedPacket represents a captured network packet.
@@ -28,6 +69,14 @@ type CapturedPacket struct {
 	Protocol    string `json:"Protocol"`
 	Length      int    `json:"Length"`
 	Summary     string `json:"Summary"` // Human-readable summary
+
+	SourceHostname      string `json:"SourceHostname"`      // Filled from the reverse DNS cache
+	DestinationHostname string `json:"DestinationHostname"` // Filled from the reverse DNS cache
+
+	SourceMAC         string `json:"SourceMAC"`
+	DestinationMAC    string `json:"DestinationMAC"`
+	SourceVendor      string `json:"SourceVendor"`      // From the OUI registry
+	DestinationVendor string `json:"DestinationVendor"` // From the OUI registry
 }
 
 // ARPEntry represents a single entry in the ARP cache.
@@ -35,7 +84,16 @@ type ARPEntry struct {
 	IPAddress   string `json:"IPAddress"`
 	MACAddress  string `json:"MACAddress"`
 	Interface   string `json:"Interface"`
-	Type        string `json:"Type"` // e.g., "dynamic", "static"
+	Type        string `json:"Type"`     // e.g., "dynamic", "static"
+	Hostname    string `json:"Hostname"` // Filled from the reverse DNS cache
+
+	Vendor              string `json:"Vendor"`              // From the OUI registry
+	LocallyAdministered bool   `json:"LocallyAdministered"` // Randomized or otherwise not IEEE-assigned MAC
+
+	Family         string `json:"Family"`         // "IPv4" (ARP) or "IPv6" (NDP)
+	State          string `json:"State"`          // Neighbor reachability, e.g., "REACHABLE", "STALE", "FAILED", "PERMANENT"
+	IsRouter       bool   `json:"IsRouter"`       // Neighbor announced itself as router (IPv6 only)
+	InterfaceIndex int    `json:"InterfaceIndex"`
 }
 
 // NetworkConnection represents an active network connection.
@@ -51,6 +109,20 @@ type NetworkConnection struct {
 	PID         int32  `json:"PID"`
 	ProcessName string `json:"ProcessName"`
 	Protocol    string `json:"Protocol"` // e.g., "tcp", "udp"
+
+	RemoteHostname string `json:"RemoteHostname"` // Filled from the reverse DNS cache
+
+	FamilyName     string `json:"FamilyName"`     // "IPv4" or "IPv6"
+	TypeName       string `json:"TypeName"`       // e.g., "SOCK_STREAM", "SOCK_DGRAM", "SOCK_RAW"
+	UID            uint32 `json:"UID"`            // Owner of the socket (Linux only)
+	Inode          uint64 `json:"Inode"`          // Socket inode (Linux only)
+	TxQueue        uint64 `json:"TxQueue"`        // Bytes in the send queue (Linux only)
+	RxQueue        uint64 `json:"RxQueue"`        // Bytes in the receive queue (Linux only)
+	Timer          string `json:"Timer"`          // Active socket timer, e.g., "retransmit", "keepalive", "time_wait" (Linux only)
+	TimerExpiresMs int64  `json:"TimerExpiresMs"` // Time until the timer expires (Linux only)
+	Retransmits    uint32 `json:"Retransmits"`    // Unrecovered retransmit timeouts (Linux only)
+
+	Process *ProcessContext `json:"Process,omitempty"` // Details of the owning process, if accessible
 }
 
 // CaptureTemplate defines a pre-configured BPF filter.
@@ -60,3 +132,427 @@ type CaptureTemplate struct {
 	BPFFilter   string `json:"bpfFilter"`
 	Duration    int    `json:"duration"`
 }
+
+// TrackerList describes an imported tracker/telemetry blocklist.
+type TrackerList struct {
+	Name        string `json:"Name"`
+	DomainCount int    `json:"DomainCount"`
+	ImportedAt  string `json:"ImportedAt"`
+}
+
+// TrackerDomainHit aggregates all observations of a single tracker domain.
+type TrackerDomainHit struct {
+	Domain    string   `json:"Domain"`
+	Lists     []string `json:"Lists"`     // Blocklists that contain the domain
+	Sources   []string `json:"Sources"`   // e.g., "dns", "sni", "rdns"
+	Processes []string `json:"Processes"` // Process names that contacted the domain
+	Count     int      `json:"Count"`
+	FirstSeen string   `json:"FirstSeen"`
+	LastSeen  string   `json:"LastSeen"`
+}
+
+// TrackerProcessHit aggregates all tracker domains contacted by a single process.
+type TrackerProcessHit struct {
+	PID         int32    `json:"PID"`
+	ProcessName string   `json:"ProcessName"`
+	Domains     []string `json:"Domains"`
+	Lists       []string `json:"Lists"`
+	Count       int      `json:"Count"`
+}
+
+// TrackerHits is the per-domain and per-process view of all tracker hits.
+type TrackerHits struct {
+	ByDomain  []TrackerDomainHit  `json:"ByDomain"`
+	ByProcess []TrackerProcessHit `json:"ByProcess"`
+}
+
+// KnownDevice is a LAN device in the persistent device inventory, identified by its MAC address.
+type KnownDevice struct {
+	MACAddress          string           `json:"MACAddress"`
+	Vendor              string           `json:"Vendor"`              // From the OUI registry
+	LocallyAdministered bool             `json:"LocallyAdministered"` // Randomized or otherwise not IEEE-assigned MAC
+	Label               string           `json:"Label"`               // User-assigned name
+	LastIPAddress       string           `json:"LastIPAddress"`
+	IPHistory           []DeviceIPRecord `json:"IPHistory"` // Oldest first
+	Sources             []string         `json:"Sources"`   // e.g., "arp-cache", "arp-scan", "sniffer", "capture"
+	FirstSeen           string           `json:"FirstSeen"`
+	LastSeen            string           `json:"LastSeen"`
+}
+
+// DeviceIPRecord is one IP address a device has used.
+type DeviceIPRecord struct {
+	IPAddress string `json:"IPAddress"`
+	FirstSeen string `json:"FirstSeen"`
+	LastSeen  string `json:"LastSeen"`
+}
+
+// ConnectionEvent is emitted by the connection watch as "connectionOpened",
+// "connectionClosed" or "connectionStateChanged" event.
+type ConnectionEvent struct {
+	Type           string            `json:"Type"` // "opened", "closed" or "stateChanged"
+	Connection     NetworkConnection `json:"Connection"`
+	PreviousStatus string            `json:"PreviousStatus"` // Only for "stateChanged"
+	FirstSeen      string            `json:"FirstSeen"`
+	LastSeen       string            `json:"LastSeen"`
+	LifetimeMs     int64             `json:"LifetimeMs"` // Observed lifetime up to this event
+	Timestamp      string            `json:"Timestamp"`
+}
+
+// WatchedConnection is a connection currently tracked by the connection watch.
+type WatchedConnection struct {
+	Connection NetworkConnection `json:"Connection"`
+	FirstSeen  string            `json:"FirstSeen"`
+	LifetimeMs int64             `json:"LifetimeMs"`
+}
+
+// ExposedService is a listening TCP socket or UDP bind found by the exposure audit.
+type ExposedService struct {
+	Protocol       string   `json:"Protocol"` // "tcp" or "udp"
+	FamilyName     string   `json:"FamilyName"`
+	LocalIP        string   `json:"LocalIP"`
+	LocalPort      uint32   `json:"LocalPort"`
+	Scope          string   `json:"Scope"` // "loopback", "lan", "all-interfaces", "ipv6-any" or "public"
+	PID            int32    `json:"PID"`
+	ProcessName    string   `json:"ProcessName"`
+	ExecutablePath string   `json:"ExecutablePath"`
+	ServiceName    string   `json:"ServiceName"` // Well-known service on the port, if any
+	Category       string   `json:"Category"`    // e.g., "database", "remote-desktop", "file-sharing", "debug"
+	Risk           string   `json:"Risk"`        // "high", "medium", "low" or "info"
+	Reasons        []string `json:"Reasons"`
+}
+
+// ExposureAudit is the result of the listening port exposure audit.
+type ExposureAudit struct {
+	GeneratedAt string           `json:"GeneratedAt"`
+	Services    []ExposedService `json:"Services"` // Highest risk first
+	HighRisk    int              `json:"HighRisk"`
+	MediumRisk  int              `json:"MediumRisk"`
+	Exposed     int              `json:"Exposed"` // Services reachable from other hosts
+}
+
+// ConnectionQuery filters, sorts, groups and pages the connection list. Empty fields match everything.
+type ConnectionQuery struct {
+	ProcessName string   `json:"ProcessName"` // Case-insensitive substring
+	PID         int32    `json:"PID"`
+	States      []string `json:"States"`    // e.g., "ESTABLISHED", "LISTEN"
+	Protocols   []string `json:"Protocols"` // e.g., "tcp", "udp"
+	LocalPort   uint32   `json:"LocalPort"`
+	RemotePort  uint32   `json:"RemotePort"`
+	RemoteCIDR  string   `json:"RemoteCIDR"` // e.g., "10.0.0.0/8" or a single IP
+	Family      string   `json:"Family"`     // "IPv4" or "IPv6"
+
+	SortBy         string `json:"SortBy"` // "processName", "pid", "protocol", "status", "localPort", "remoteIP", "remotePort"
+	SortDescending bool   `json:"SortDescending"`
+	GroupBy        string `json:"GroupBy"` // "", "process" or "remoteHost"
+
+	Offset int `json:"Offset"`
+	Limit  int `json:"Limit"` // 0 returns all rows (or groups)
+}
+
+// ConnectionGroup is one group of a grouped connection query.
+type ConnectionGroup struct {
+	Key         string              `json:"Key"`      // Process ("pid/name") or remote IP
+	Hostname    string              `json:"Hostname"` // Reverse DNS name of the remote IP, if grouped by remote host
+	Count       int                 `json:"Count"`
+	Connections []NetworkConnection `json:"Connections"`
+}
+
+// ConnectionQueryResult is one page of a connection query.
+type ConnectionQueryResult struct {
+	Total       int                 `json:"Total"`       // Connections matching the filters
+	TotalGroups int                 `json:"TotalGroups"` // Groups, if grouped
+	Offset      int                 `json:"Offset"`
+	Limit       int                 `json:"Limit"`
+	Connections []NetworkConnection `json:"Connections"` // Page of connections, if not grouped
+	Groups      []ConnectionGroup   `json:"Groups"`      // Page of groups, if grouped
+}
+
+// ProcessContext describes a process beyond its short name.
+type ProcessContext struct {
+	PID            int32             `json:"PID"`
+	Name           string            `json:"Name"`
+	ExecutablePath string            `json:"ExecutablePath"`
+	Cmdline        string            `json:"Cmdline"`
+	Username       string            `json:"Username"`
+	StartTime      string            `json:"StartTime"`
+	Parents        []ProcessAncestor `json:"Parents"` // Direct parent first
+	SHA256         string            `json:"SHA256"`  // Of the executable; empty until requested via GetProcessContext
+}
+
+// ProcessAncestor is one entry of the parent process chain.
+type ProcessAncestor struct {
+	PID            int32  `json:"PID"`
+	Name           string `json:"Name"`
+	ExecutablePath string `json:"ExecutablePath"`
+}
+
+// UnixSocket is a Unix domain socket used for local inter-process communication.
+type UnixSocket struct {
+	Path        string `json:"Path"`     // Filesystem path, "@name" for abstract sockets, empty if unnamed
+	Abstract    bool   `json:"Abstract"` // Bound in the abstract namespace instead of the filesystem
+	TypeName    string `json:"TypeName"` // "SOCK_STREAM", "SOCK_DGRAM" or "SOCK_SEQPACKET"
+	State       string `json:"State"`    // "LISTEN", "CONNECTED", "CONNECTING", "DISCONNECTING" or "UNCONNECTED"
+	Inode       uint64 `json:"Inode"`
+	UID         uint32 `json:"UID"`      // Owner of the socket, valid if UIDKnown is set
+	UIDKnown    bool   `json:"UIDKnown"` // Not reported by kernels without unix_diag
+	PID         int32  `json:"PID"`
+	ProcessName string `json:"ProcessName"`
+
+	PeerInode       uint64 `json:"PeerInode"` // Socket at the other end of a connection, 0 if unknown
+	PeerPID         int32  `json:"PeerPID"`
+	PeerProcessName string `json:"PeerProcessName"`
+}
+
+// RawSocket is an AF_PACKET socket or a raw IP socket. Such sockets can read traffic
+// that is not addressed to the owning process, e.g., packet sniffers or ICMP tools.
+type RawSocket struct {
+	Kind           string `json:"Kind"`           // "packet" (AF_PACKET) or "raw" (raw IP socket)
+	FamilyName     string `json:"FamilyName"`     // "packet", "IPv4" or "IPv6"
+	TypeName       string `json:"TypeName"`       // "SOCK_RAW" or "SOCK_DGRAM"
+	Protocol       string `json:"Protocol"`       // e.g., "ETH_P_ALL", "ETH_P_ARP", "icmp", "icmpv6"
+	ProtocolNumber uint32 `json:"ProtocolNumber"` // EtherType for packet sockets, IP protocol for raw sockets
+	Interface      string `json:"Interface"`      // Bound interface, empty for all interfaces
+	LocalIP        string `json:"LocalIP"`        // Raw sockets only
+	RemoteIP       string `json:"RemoteIP"`       // Raw sockets only
+	Inode          uint64 `json:"Inode"`
+	UID            uint32 `json:"UID"`
+	PID            int32  `json:"PID"`
+	ProcessName    string `json:"ProcessName"`
+
+	// CapturesAllTraffic is set for packet sockets that receive every frame (ETH_P_ALL)
+	// on one or all interfaces, which is what capture tools like tcpdump use.
+	CapturesAllTraffic bool `json:"CapturesAllTraffic"`
+}
+
+// ConnectionRecord is one connection lifecycle in the connection history.
+type ConnectionRecord struct {
+	Protocol       string `json:"Protocol"`
+	FamilyName     string `json:"FamilyName"`
+	LocalIP        string `json:"LocalIP"`
+	LocalPort      uint32 `json:"LocalPort"`
+	RemoteIP       string `json:"RemoteIP"`
+	RemotePort     uint32 `json:"RemotePort"`
+	RemoteHostname string `json:"RemoteHostname,omitempty"`
+	PID            int32  `json:"PID"`
+	ProcessName    string `json:"ProcessName"`
+	ExecutablePath string `json:"ExecutablePath,omitempty"`
+	LastStatus     string `json:"LastStatus"`
+	OpenedAt       string `json:"OpenedAt"`
+	ClosedAt       string `json:"ClosedAt,omitempty"` // Empty while the connection is still open
+	DurationMs     int64  `json:"DurationMs"`
+}
+
+// ConnectionHistoryQuery selects records from the connection history. Empty fields match everything.
+type ConnectionHistoryQuery struct {
+	ProcessName string `json:"ProcessName"` // Case-insensitive substring
+	PID         int32  `json:"PID"`
+	RemoteHost  string `json:"RemoteHost"` // Case-insensitive substring of remote IP or hostname
+	RemotePort  uint32 `json:"RemotePort"`
+	Protocol    string `json:"Protocol"` // "tcp" or "udp"
+	Since       string `json:"Since"`    // RFC3339; takes precedence over Hours
+	Until       string `json:"Until"`    // RFC3339
+	Hours       int    `json:"Hours"`    // Only records of the last N hours
+	Limit       int    `json:"Limit"`    // 0 means no limit
+}
+
+// ContactedHost aggregates the history records of one remote host.
+type ContactedHost struct {
+	RemoteIP       string   `json:"RemoteIP"`
+	RemoteHostname string   `json:"RemoteHostname"`
+	Ports          []uint32 `json:"Ports"`
+	Processes      []string `json:"Processes"`
+	Connections    int      `json:"Connections"`
+	FirstSeen      string   `json:"FirstSeen"`
+	LastSeen       string   `json:"LastSeen"`
+}
+
+// ConnectionHistorySettings configures the connection history recorder.
+type ConnectionHistorySettings struct {
+	Enabled         bool `json:"Enabled"` // Recording resumes on the next start
+	IntervalSeconds int  `json:"IntervalSeconds"`
+	RetentionDays   int  `json:"RetentionDays"`
+	MaxSizeMB       int  `json:"MaxSizeMB"`
+}
+
+// ConnectionHistoryStatus describes the state of the connection history recorder.
+type ConnectionHistoryStatus struct {
+	Running         bool                      `json:"Running"`
+	Settings        ConnectionHistorySettings `json:"Settings"`
+	OpenConnections int                       `json:"OpenConnections"`
+	SizeBytes       int64                     `json:"SizeBytes"`
+	OldestDay       string                    `json:"OldestDay"` // Date of the oldest history file, YYYY-MM-DD
+}
+
+// InterfaceCounters are the cumulative traffic counters of a network interface.
+type InterfaceCounters struct {
+	RxBytes   uint64 `json:"RxBytes"`
+	TxBytes   uint64 `json:"TxBytes"`
+	RxPackets uint64 `json:"RxPackets"`
+	TxPackets uint64 `json:"TxPackets"`
+	RxErrors  uint64 `json:"RxErrors"`
+	TxErrors  uint64 `json:"TxErrors"`
+	RxDropped uint64 `json:"RxDropped"`
+	TxDropped uint64 `json:"TxDropped"`
+	Multicast uint64 `json:"Multicast"` // Received multicast packets (Linux only)
+}
+
+// InterfaceRate is the average throughput of an interface over a time window.
+type InterfaceRate struct {
+	WindowSeconds   int     `json:"WindowSeconds"`
+	ElapsedSeconds  float64 `json:"ElapsedSeconds"` // Actual span of the samples; shorter than the window until enough samples exist
+	RxBytesPerSec   float64 `json:"RxBytesPerSec"`
+	TxBytesPerSec   float64 `json:"TxBytesPerSec"`
+	RxPacketsPerSec float64 `json:"RxPacketsPerSec"`
+	TxPacketsPerSec float64 `json:"TxPacketsPerSec"`
+	ErrorsPerSec    float64 `json:"ErrorsPerSec"`  // Receive and transmit errors
+	DroppedPerSec   float64 `json:"DroppedPerSec"` // Receive and transmit drops
+}
+
+// InterfaceStats is emitted as "interfaceThroughput" event for every interface.
+type InterfaceStats struct {
+	Name      string            `json:"Name"`
+	Counters  InterfaceCounters `json:"Counters"`
+	Rates     []InterfaceRate   `json:"Rates"` // One entry per configured window
+	Timestamp string            `json:"Timestamp"`
+}
+
+// WirelessInfo describes the Wi-Fi connection of a wireless interface.
+type WirelessInfo struct {
+	Interface     string  `json:"Interface"`
+	Mode          string  `json:"Mode"` // e.g., "station", "ap", "monitor"
+	Connected     bool    `json:"Connected"`
+	SSID          string  `json:"SSID"`
+	BSSID         string  `json:"BSSID"`
+	FrequencyMHz  int     `json:"FrequencyMHz"`
+	Channel       int     `json:"Channel"`
+	Band          string  `json:"Band"` // "2.4 GHz", "5 GHz", "6 GHz" or "60 GHz"
+	SignalDBm     int     `json:"SignalDBm"`
+	NoiseDBm      int     `json:"NoiseDBm"` // 0 if not reported by the driver
+	TxBitrateMbps float64 `json:"TxBitrateMbps"`
+	RxBitrateMbps float64 `json:"RxBitrateMbps"`
+	Security      string  `json:"Security"` // "open", "OWE", "WEP", "WPA", "WPA2", "WPA2/WPA3", "WPA3", "WPA2-Enterprise" or "WPA3-Enterprise"; empty if unknown
+	IsOpen        bool    `json:"IsOpen"`   // Traffic is not encrypted on the air
+	Source        string  `json:"Source"`   // "nl80211" or "proc" (/proc/net/wireless, signal and noise only)
+}
+
+// Route is one entry of a routing table.
+type Route struct {
+	Family          string         `json:"Family"`      // "IPv4" or "IPv6"
+	Destination     string         `json:"Destination"` // CIDR, e.g., "0.0.0.0/0" for the default route
+	IsDefault       bool           `json:"IsDefault"`
+	Gateway         string         `json:"Gateway"` // Empty for directly connected networks
+	Interface       string         `json:"Interface"`
+	InterfaceIndex  int            `json:"InterfaceIndex"`
+	PreferredSource string         `json:"PreferredSource"`
+	Metric          uint32         `json:"Metric"`
+	Table           string         `json:"Table"` // "main", "local", "default" or the table number
+	TableID         uint32         `json:"TableID"`
+	Protocol        string         `json:"Protocol"` // Origin of the route, e.g., "kernel", "boot", "static", "dhcp", "ra"
+	Scope           string         `json:"Scope"`    // "universe", "site", "link", "host" or "nowhere"
+	Type            string         `json:"Type"`     // e.g., "unicast", "local", "broadcast", "unreachable", "blackhole"
+	Nexthops        []RouteNexthop `json:"Nexthops"` // Multipath routes only
+}
+
+// RouteNexthop is one path of a multipath route.
+type RouteNexthop struct {
+	Gateway        string `json:"Gateway"`
+	Interface      string `json:"Interface"`
+	InterfaceIndex int    `json:"InterfaceIndex"`
+	Weight         int    `json:"Weight"`
+}
+
+// RoutingRule is a policy routing rule ("ip rule") that selects the routing table.
+type RoutingRule struct {
+	Family          string `json:"Family"`
+	Priority        uint32 `json:"Priority"`
+	Source          string `json:"Source"`      // CIDR, empty for all
+	Destination     string `json:"Destination"` // CIDR, empty for all
+	InputInterface  string `json:"InputInterface"`
+	OutputInterface string `json:"OutputInterface"`
+	FwMark          uint32 `json:"FwMark"`
+	FwMask          uint32 `json:"FwMask"`
+	Invert          bool   `json:"Invert"` // The selector is negated ("not")
+	Action          string `json:"Action"` // "lookup", "goto", "nop", "blackhole", "unreachable" or "prohibit"
+	Table           string `json:"Table"`
+	TableID         uint32 `json:"TableID"`
+	Goto            uint32 `json:"Goto"`           // Target priority of "goto" rules
+	SuppressPrefix  int    `json:"SuppressPrefix"` // Routes with this prefix length or shorter are ignored, -1 if unset
+}
+
+// RouteLookup is the route the kernel selects for a destination.
+type RouteLookup struct {
+	Destination   string `json:"Destination"` // The resolved IP address
+	Route         Route  `json:"Route"`
+	SourceAddress string `json:"SourceAddress"` // Local address used for new connections
+}
+
+// DefaultGateway is the next hop of a default route.
+type DefaultGateway struct {
+	Family     string `json:"Family"`  // "IPv4" or "IPv6"
+	Gateway    string `json:"Gateway"` // Empty for point-to-point defaults, e.g., "default dev wg0"
+	Interface  string `json:"Interface"`
+	MACAddress string `json:"MACAddress"` // From the neighbor table, empty if not resolved
+	Vendor     string `json:"Vendor"`
+	Metric     uint32 `json:"Metric"`
+	Table      string `json:"Table"`
+	IsPrimary  bool   `json:"IsPrimary"` // Used for internet traffic of its family according to a route lookup
+}
+
+// DNSConfig describes how the system resolves host names.
+type DNSConfig struct {
+	ResolvConf        ResolvConf          `json:"ResolvConf"`
+	ResolvedActive    bool                `json:"ResolvedActive"` // systemd-resolved status could be read
+	Resolvers         []DNSResolverConfig `json:"Resolvers"`      // Effective resolvers: a "global" scope and one per interface with own settings
+	NSSwitchHosts     []string            `json:"NSSwitchHosts"`  // Sources of the "hosts:" line in /etc/nsswitch.conf, e.g., "files", "resolve", "dns"
+	HostsEntries      []HostsEntry        `json:"HostsEntries"`
+	HostsBlockedCount int                 `json:"HostsBlockedCount"` // Host names mapped to 0.0.0.0 or :: (blocklists), not listed in HostsEntries
+}
+
+// ResolvConf is the parsed /etc/resolv.conf.
+type ResolvConf struct {
+	Path          string   `json:"Path"`
+	SymlinkTarget string   `json:"SymlinkTarget"`
+	ManagedBy     string   `json:"ManagedBy"` // "systemd-resolved", "NetworkManager", "resolvconf", "netconfig" or empty
+	Nameservers   []string `json:"Nameservers"`
+	SearchDomains []string `json:"SearchDomains"`
+	Options       []string `json:"Options"`
+	IsLocalStub   bool     `json:"IsLocalStub"` // All queries go to a resolver on a loopback address
+	StubAddress   string   `json:"StubAddress"` // e.g., "127.0.0.53" for systemd-resolved
+	Error         string   `json:"Error"`       // Set if the file could not be read; the other fields are then empty
+}
+
+// DNSResolverConfig holds the DNS servers and settings of one resolver scope.
+type DNSResolverConfig struct {
+	Scope           string   `json:"Scope"` // "global" or the interface name
+	InterfaceIndex  int      `json:"InterfaceIndex"`
+	Source          string   `json:"Source"` // "systemd-resolved" or "resolv.conf"
+	Servers         []string `json:"Servers"`
+	CurrentServer   string   `json:"CurrentServer"`
+	FallbackServers []string `json:"FallbackServers"`
+	Domains         []string `json:"Domains"`      // Search and routing domains; routing-only domains start with "~"
+	DefaultRoute    bool     `json:"DefaultRoute"` // Used for names that match no routing domain
+	DNSSEC          string   `json:"DNSSEC"`       // e.g., "no", "allow-downgrade", "yes"
+	DNSSECSupported bool     `json:"DNSSECSupported"`
+	DNSOverTLS      string   `json:"DNSOverTLS"` // "no", "opportunistic" or "yes"
+	LLMNR           string   `json:"LLMNR"`
+	MulticastDNS    string   `json:"MulticastDNS"`
+}
+
+// HostsEntry is a line of /etc/hosts.
+type HostsEntry struct {
+	IPAddress string   `json:"IPAddress"`
+	Hostnames []string `json:"Hostnames"`
+}
+
+// NetworkChange is an entry of the network change timeline.
+type NetworkChange struct {
+	Timestamp      string `json:"Timestamp"`
+	Type           string `json:"Type"` // "link-added", "link-removed", "link-up", "link-down", "vpn-up", "vpn-down", "address-added", "address-removed", "default-route-added", "default-route-removed", "rule-added", "rule-removed" or "dns-changed"
+	Interface      string `json:"Interface"`
+	InterfaceIndex int    `json:"InterfaceIndex"`
+	Kind           string `json:"Kind"` // Link kind, e.g., "wireguard", "tun", "bridge"; empty for hardware devices
+	IsVPN          bool   `json:"IsVPN"`
+	Family         string `json:"Family"`
+	Address        string `json:"Address"` // CIDR of an address, gateway of a default route
+	Details        string `json:"Details"` // Human-readable summary
+}
@@ -0,0 +1,12 @@
+package network
+
+import "context"
+
+// NetworkChangeMonitor defines the interface for receiving network configuration changes
+// from the operating system. The channels are closed when ctx is cancelled.
+type NetworkChangeMonitor interface {
+	WatchNetworkChanges(ctx context.Context) (<-chan NetworkChange, error)
+	// WatchDNSChanges signals when the resolver configuration may have changed. Bursts of
+	// file system events are coalesced into one signal.
+	WatchDNSChanges(ctx context.Context) (<-chan struct{}, error)
+}
@@ -0,0 +1,340 @@
+package network
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	networkChangeDirName      = "changes"
+	networkChangeTimelineFile = "timeline.jsonl"
+	networkChangeTimelineSize = 5000
+	networkChangeDNSInterval  = 10 * time.Second       // Polling interval if the resolver files cannot be watched
+	networkChangeDNSSettle    = 500 * time.Millisecond // Delay between a resolver file change and the check
+)
+
+// NetworkChangeService records the changes reported by the platform's NetworkChangeMonitor
+// and resolver changes found by checking the DNS configuration when its files change. Every
+// change is emitted as "networkChanged" event and appended to a timeline in the config
+// directory that keeps the newest networkChangeTimelineSize entries.
+type NetworkChangeService struct {
+	appCtx     context.Context
+	monitor    NetworkChangeMonitor
+	dnsService DNSConfigService
+
+	mu        sync.Mutex
+	stop      context.CancelFunc
+	timeline  []NetworkChange // Oldest first
+	fileLines int             // Entries in the timeline file, including trimmed ones
+	resolvers string          // Fingerprint of the last seen resolvers
+	dnsPolled bool
+}
+
+// NewNetworkChangeService creates a new NetworkChangeService with the persisted timeline.
+func NewNetworkChangeService(monitor NetworkChangeMonitor, dnsService DNSConfigService) *NetworkChangeService {
+	s := &NetworkChangeService{monitor: monitor, dnsService: dnsService}
+	if err := s.loadTimeline(); err != nil {
+		log.Printf("WARN: Could not load network change timeline: %v", err)
+	}
+	return s
+}
+
+// WailsInit stores the application context and starts monitoring.
+func (s *NetworkChangeService) WailsInit(ctx context.Context) {
+	s.appCtx = ctx
+	if err := s.StartNetworkChangeMonitor(); err != nil && !errors.Is(err, ErrNotSupported) {
+		log.Printf("WARN: Could not start network change monitor: %v", err)
+	}
+}
+
+// StartNetworkChangeMonitor subscribes to the change notifications of the operating system.
+func (s *NetworkChangeService) StartNetworkChangeMonitor() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil {
+		return fmt.Errorf("network change monitor is already running")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	changes, err := s.monitor.WatchNetworkChanges(ctx)
+	if err != nil {
+		cancel()
+		return err
+	}
+	s.stop = cancel
+	s.dnsPolled = false
+	go s.receiveLoop(changes)
+	go s.dnsLoop(ctx)
+	log.Println("Network change monitor started.")
+	return nil
+}
+
+// StopNetworkChangeMonitor stops monitoring. The timeline is kept.
+func (s *NetworkChangeService) StopNetworkChangeMonitor() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil {
+		s.stop()
+		s.stop = nil
+		log.Println("Network change monitor stopped.")
+	}
+}
+
+// Shutdown stops monitoring when the application exits.
+func (s *NetworkChangeService) Shutdown() {
+	s.StopNetworkChangeMonitor()
+}
+
+// IsNetworkChangeMonitorRunning reports whether the monitor is active.
+func (s *NetworkChangeService) IsNetworkChangeMonitorRunning() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stop != nil
+}
+
+// GetNetworkChanges returns up to limit timeline entries, newest first (0 returns all).
+func (s *NetworkChangeService) GetNetworkChanges(limit int) []NetworkChange {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if limit <= 0 || limit > len(s.timeline) {
+		limit = len(s.timeline)
+	}
+	changes := make([]NetworkChange, 0, limit)
+	for i := len(s.timeline) - 1; i >= len(s.timeline)-limit; i-- {
+		changes = append(changes, s.timeline[i])
+	}
+	return changes
+}
+
+// ClearNetworkChanges deletes the timeline.
+func (s *NetworkChangeService) ClearNetworkChanges() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path, err := networkChangeTimelinePath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete %s: %w", path, err)
+	}
+	s.timeline = nil
+	s.fileLines = 0
+	return nil
+}
+
+func (s *NetworkChangeService) receiveLoop(changes <-chan NetworkChange) {
+	for change := range changes {
+		s.record(change)
+	}
+}
+
+// dnsLoop checks the DNS configuration whenever the monitor reports a change of the resolver
+// files, because resolver changes are not part of the routing notifications. If the files
+// cannot be watched, the configuration is polled every networkChangeDNSInterval instead.
+func (s *NetworkChangeService) dnsLoop(ctx context.Context) {
+	if !s.checkDNSConfig() {
+		return
+	}
+
+	notifications, err := s.monitor.WatchDNSChanges(ctx)
+	if err != nil {
+		if !errors.Is(err, ErrNotSupported) {
+			log.Printf("WARN: Could not watch DNS configuration, polling instead: %v", err)
+		}
+		s.pollDNSConfig(ctx)
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case _, ok := <-notifications:
+			if !ok {
+				return
+			}
+			// Let the writer finish, e.g., NetworkManager and systemd-resolved rewriting in turn
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(networkChangeDNSSettle):
+			}
+			s.checkDNSConfig()
+		}
+	}
+}
+
+// pollDNSConfig checks the DNS configuration every networkChangeDNSInterval until ctx is cancelled.
+func (s *NetworkChangeService) pollDNSConfig(ctx context.Context) {
+	ticker := time.NewTicker(networkChangeDNSInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.checkDNSConfig()
+		}
+	}
+}
+
+// checkDNSConfig reads the DNS configuration and compares the resolvers with the last check.
+// It returns false if the platform cannot read the DNS configuration.
+func (s *NetworkChangeService) checkDNSConfig() bool {
+	config, err := s.dnsService.GetDNSConfig()
+	if errors.Is(err, ErrNotSupported) {
+		return false
+	}
+	if err == nil {
+		s.checkResolvers(config)
+	}
+	return true
+}
+
+// checkResolvers records a "dns-changed" entry if the effective resolvers differ from the last poll.
+func (s *NetworkChangeService) checkResolvers(config *DNSConfig) {
+	var scopes []string
+	for _, resolver := range config.Resolvers {
+		scope := fmt.Sprintf("%s: %s", resolver.Scope, strings.Join(resolver.Servers, " "))
+		if len(resolver.Domains) > 0 {
+			scope += " (" + strings.Join(resolver.Domains, " ") + ")"
+		}
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+	fingerprint := strings.Join(scopes, "; ")
+
+	s.mu.Lock()
+	previous, polled := s.resolvers, s.dnsPolled
+	s.resolvers, s.dnsPolled = fingerprint, true
+	s.mu.Unlock()
+
+	if !polled || previous == fingerprint {
+		return
+	}
+	s.record(NetworkChange{
+		Timestamp: time.Now().Format(time.RFC3339),
+		Type:      "dns-changed",
+		Details:   "DNS resolvers changed to " + fingerprint,
+	})
+}
+
+// record appends a change to the timeline and its file and emits it.
+func (s *NetworkChangeService) record(change NetworkChange) {
+	s.mu.Lock()
+	s.timeline = append(s.timeline, change)
+	if overflow := len(s.timeline) - networkChangeTimelineSize; overflow > 0 {
+		s.timeline = append([]NetworkChange(nil), s.timeline[overflow:]...)
+	}
+	if err := s.appendTimelineLocked(change); err != nil {
+		log.Printf("WARN: Could not write network change timeline: %v", err)
+	}
+	s.mu.Unlock()
+
+	if s.appCtx != nil {
+		runtime.EventsEmit(s.appCtx, "networkChanged", change)
+	}
+}
+
+// appendTimelineLocked appends a change to the timeline file. The file is rewritten with the
+// in-memory timeline once it holds twice the kept number of entries.
+func (s *NetworkChangeService) appendTimelineLocked(change NetworkChange) error {
+	path, err := networkChangeTimelinePath()
+	if err != nil {
+		return err
+	}
+	if s.fileLines >= 2*networkChangeTimelineSize {
+		return s.rewriteTimelineLocked(path)
+	}
+
+	data, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	_, err = file.Write(append(data, '\n'))
+	file.Close()
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	s.fileLines++
+	return nil
+}
+
+// rewriteTimelineLocked replaces the timeline file with the in-memory timeline.
+func (s *NetworkChangeService) rewriteTimelineLocked(path string) error {
+	var data []byte
+	for _, change := range s.timeline {
+		line, err := json.Marshal(change)
+		if err != nil {
+			return err
+		}
+		data = append(append(data, line...), '\n')
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", tmpPath, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+	s.fileLines = len(s.timeline)
+	return nil
+}
+
+// loadTimeline reads the newest entries of the timeline file. Malformed lines are skipped.
+func (s *NetworkChangeService) loadTimeline() error {
+	path, err := networkChangeTimelinePath()
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		s.fileLines++
+		var change NetworkChange
+		if err := json.Unmarshal(scanner.Bytes(), &change); err != nil {
+			continue
+		}
+		s.timeline = append(s.timeline, change)
+	}
+	if overflow := len(s.timeline) - networkChangeTimelineSize; overflow > 0 {
+		s.timeline = append([]NetworkChange(nil), s.timeline[overflow:]...)
+	}
+	return scanner.Err()
+}
+
+func networkChangeTimelinePath() (string, error) {
+	dir, err := appConfigDir(networkChangeDirName)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, networkChangeTimelineFile), nil
+}
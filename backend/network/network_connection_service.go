@@ -1,15 +1,23 @@
 package network
 
-import (
-)
-
 // NetworkConnectionService defines the interface for listing network connections.
 type NetworkConnectionService interface {
 	GetConnections() ([]NetworkConnection, error)
 }
 
-// NewNetworkConnectionService creates a new instance of NetworkConnectionService based on the operating system.
-// This function is implemented in platform-specific files (e.g., network_connection_service_darwin.go, etc.).
-func NewNetworkConnectionService() NetworkConnectionService {
-	return nil // Should never be reached
-}
\ No newline at end of file
+// ConnectionProtocol returns the protocol of a connection ("tcp", "udp", "raw"), deriving
+// it from the socket type on platforms that do not fill the Protocol field.
+func ConnectionProtocol(conn NetworkConnection) string {
+	if conn.Protocol != "" {
+		return conn.Protocol
+	}
+	switch conn.Type {
+	case 1: // SOCK_STREAM
+		return "tcp"
+	case 2: // SOCK_DGRAM
+		return "udp"
+	case 3: // SOCK_RAW
+		return "raw"
+	}
+	return ""
+}
@@ -2,7 +2,9 @@ package network
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"time"
 )
 
@@ -13,16 +15,37 @@ type NetworkDashboardService struct {
 	networkInterfaceService NetworkInterfaceService
 	arpCacheService ARPCacheService
 	networkConnectionService NetworkConnectionService
+	localSocketService LocalSocketService
+	interfaceCounterService InterfaceCounterService
+	wirelessService WirelessService
+	routingService RoutingService
+	dnsConfigService DNSConfigService
 }
 
-// Wails ruft diese Methode nach dem Binden automatisch auf
-func (s *NetworkDashboardService) Init() {
-	s.publicIPService = PublicIPService{}
-	s.localIPService = NetInfoService{}
-	s.packetCaptureService = NewPacketCaptureService()
-	s.networkInterfaceService = NewNetworkInterfaceService()
-	s.arpCacheService = NewARPCacheService()
-	s.networkConnectionService = NewNetworkConnectionService()
+// NewNetworkDashboardService creates the dashboard service with the injected platform services.
+// Missing implementations are replaced by services that report ErrNotSupported.
+func NewNetworkDashboardService(services PlatformServices) *NetworkDashboardService {
+	services = services.WithDefaults()
+	return &NetworkDashboardService{
+		publicIPService:          PublicIPService{},
+		localIPService:           NetInfoService{},
+		packetCaptureService:     services.PacketCapture,
+		networkInterfaceService:  services.NetworkInterfaces,
+		arpCacheService:          services.ARPCache,
+		networkConnectionService: services.Connections,
+		localSocketService:       services.LocalSockets,
+		interfaceCounterService:  services.InterfaceCounters,
+		wirelessService:          services.Wireless,
+		routingService:           services.Routing,
+		dnsConfigService:         services.DNSConfig,
+	}
+}
+
+// WailsInit passes the application context to platform services that emit events.
+func (s *NetworkDashboardService) WailsInit(ctx context.Context) {
+	if initializer, ok := s.packetCaptureService.(interface{ WailsInit(context.Context) }); ok {
+		initializer.WailsInit(ctx)
+	}
 }
 
 func (s *NetworkDashboardService) GetPublicIP() string {
@@ -76,17 +99,203 @@ func (s *NetworkDashboardService) StopPacketCapture() error {
 	return s.packetCaptureService.StopCapture()
 }
 
-// GetNetworkInterfaces lists all network interfaces.
+// GetNetworkInterfaces lists all network interfaces with their traffic counters.
 func (s *NetworkDashboardService) GetNetworkInterfaces() ([]NetworkInterface, error) {
-	return s.networkInterfaceService.ListInterfaces()
+	interfaces, err := s.networkInterfaceService.ListInterfaces()
+	if err != nil {
+		return nil, err
+	}
+	counters, err := s.interfaceCounterService.GetInterfaceCounters()
+	if err != nil {
+		log.Printf("WARN: Could not get interface counters: %v", err)
+	}
+	vendors := GetVendorService()
+	for i := range interfaces {
+		vendor := vendors.LookupVendor(interfaces[i].HardwareAddr.String())
+		interfaces[i].Vendor = vendor.Vendor
+		interfaces[i].LocallyAdministered = vendor.LocallyAdministered
+		if c, ok := counters[interfaces[i].Name]; ok {
+			interfaces[i].Counters = &c
+		}
+		if interfaces[i].Addresses == nil {
+			interfaces[i].Addresses = InterfaceAddressesFromCIDRs(interfaces[i].Addrs)
+		}
+	}
+	s.attachWirelessInfo(interfaces)
+	return interfaces, nil
 }
 
 // GetARPEntries retrieves all ARP cache entries.
+// Hostnames not yet in the reverse DNS cache are pushed later via "reverseDNSResolved" events.
 func (s *NetworkDashboardService) GetARPEntries() ([]ARPEntry, error) {
-	return s.arpCacheService.GetARPEntries()
+	entries, err := s.arpCacheService.GetARPEntries()
+	if err != nil {
+		return nil, err
+	}
+	rdns := GetReverseDNSService()
+	vendors := GetVendorService()
+	inventory := GetDeviceInventoryService()
+	for i := range entries {
+		if entries[i].State != "INCOMPLETE" && entries[i].State != "FAILED" {
+			inventory.ObserveDevice(entries[i].MACAddress, entries[i].IPAddress, "arp-cache")
+		}
+		entries[i].Hostname = rdns.Hostname(entries[i].IPAddress)
+		vendor := vendors.LookupVendor(entries[i].MACAddress)
+		entries[i].Vendor = vendor.Vendor
+		entries[i].LocallyAdministered = vendor.LocallyAdministered
+	}
+	return entries, nil
 }
 
-// GetNetworkConnections retrieves all active network connections.
+// GetNetworkConnections retrieves all active network connections with the context of their processes.
+// Hostnames not yet in the reverse DNS cache are pushed later via "reverseDNSResolved" events.
 func (s *NetworkDashboardService) GetNetworkConnections() ([]NetworkConnection, error) {
-	return s.networkConnectionService.GetConnections()
-}
\ No newline at end of file
+	conns, err := s.networkConnectionService.GetConnections()
+	if err != nil {
+		return nil, err
+	}
+	enrichConnections(conns)
+	return conns, nil
+}
+
+// QueryConnections filters, sorts, groups and pages the active network connections on the backend.
+// Only the connections of the returned page are enriched with hostnames and process context.
+func (s *NetworkDashboardService) QueryConnections(query ConnectionQuery) (*ConnectionQueryResult, error) {
+	conns, err := s.networkConnectionService.GetConnections()
+	if err != nil {
+		return nil, err
+	}
+	result, err := QueryConnectionList(conns, query)
+	if err != nil {
+		return nil, err
+	}
+	enrichConnections(result.Connections)
+	rdns := GetReverseDNSService()
+	for i := range result.Groups {
+		enrichConnections(result.Groups[i].Connections)
+		if query.GroupBy == "remoteHost" {
+			result.Groups[i].Hostname = rdns.Hostname(result.Groups[i].Key)
+		}
+	}
+	return result, nil
+}
+
+// enrichConnections adds the remote hostnames and the context of the owning processes.
+func enrichConnections(conns []NetworkConnection) {
+	rdns := GetReverseDNSService()
+	processes := GetProcessContextService()
+	contexts := make(map[int32]*ProcessContext)
+	for i := range conns {
+		conns[i].RemoteHostname = rdns.Hostname(conns[i].RemoteIP)
+		if pid := conns[i].PID; pid > 0 {
+			ctx, ok := contexts[pid]
+			if !ok {
+				ctx = processes.Lookup(pid)
+				contexts[pid] = ctx
+			}
+			conns[i].Process = ctx
+		}
+	}
+}
+
+// GetProcessContext returns executable path, command line, user, start time, parent chain
+// and executable SHA-256 of a process.
+func (s *NetworkDashboardService) GetProcessContext(pid int32) (*ProcessContext, error) {
+	return GetProcessContextService().GetProcessContext(pid)
+}
+
+// GetUnixSockets lists the Unix domain sockets with their owning and peer processes.
+func (s *NetworkDashboardService) GetUnixSockets() ([]UnixSocket, error) {
+	return s.localSocketService.GetUnixSockets()
+}
+
+// GetRawSockets lists AF_PACKET and raw IP sockets, i.e., processes that can sniff traffic.
+func (s *NetworkDashboardService) GetRawSockets() ([]RawSocket, error) {
+	return s.localSocketService.GetRawSockets()
+}
+
+// GetWirelessInfo returns SSID, BSSID, channel, signal, bitrate and security of the Wi-Fi interfaces.
+func (s *NetworkDashboardService) GetWirelessInfo() ([]WirelessInfo, error) {
+	return s.wirelessService.GetWirelessInfo()
+}
+
+// attachWirelessInfo adds the Wi-Fi connection to wireless interfaces.
+func (s *NetworkDashboardService) attachWirelessInfo(interfaces []NetworkInterface) {
+	hasWireless := false
+	for _, iface := range interfaces {
+		hasWireless = hasWireless || iface.Kind == "wireless"
+	}
+	if !hasWireless {
+		return
+	}
+	infos, err := s.wirelessService.GetWirelessInfo()
+	if err != nil {
+		log.Printf("WARN: Could not get Wi-Fi details: %v", err)
+		return
+	}
+	for i := range infos {
+		for j := range interfaces {
+			if interfaces[j].Name == infos[i].Interface {
+				interfaces[j].Wireless = &infos[i]
+			}
+		}
+	}
+}
+
+// GetRoutes returns the IPv4 and IPv6 routes of all routing tables.
+func (s *NetworkDashboardService) GetRoutes() ([]Route, error) {
+	return s.routingService.GetRoutes()
+}
+
+// GetRoutingRules returns the policy routing rules that select the routing table.
+func (s *NetworkDashboardService) GetRoutingRules() ([]RoutingRule, error) {
+	return s.routingService.GetRoutingRules()
+}
+
+// LookupRoute returns the route and source address the system would use for a destination IP or hostname.
+func (s *NetworkDashboardService) LookupRoute(destination string) (*RouteLookup, error) {
+	return s.routingService.LookupRoute(destination)
+}
+
+// GetDefaultGateways returns the IPv4 and IPv6 default gateways with their MAC addresses and
+// metrics. The primary gateway of each family is the one a route lookup for a public address
+// selects. Without a routing table implementation only the IPv4 gateway is reported.
+func (s *NetworkDashboardService) GetDefaultGateways() ([]DefaultGateway, error) {
+	neighbors, err := s.arpCacheService.GetARPEntries()
+	if err != nil && !errors.Is(err, ErrNotSupported) {
+		return nil, err
+	}
+
+	routes, err := s.routingService.GetRoutes()
+	routesUnsupported := errors.Is(err, ErrNotSupported)
+	if routesUnsupported {
+		ip, gwErr := DefaultGatewayIP()
+		if gwErr != nil {
+			return nil, gwErr
+		}
+		routes, err = []Route{{Family: "IPv4", Destination: "0.0.0.0/0", IsDefault: true, Gateway: ip, Table: "main"}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var primaryRoutes map[string]Route
+	if !routesUnsupported {
+		primaryRoutes = make(map[string]Route)
+		for family, probe := range primaryRouteProbes {
+			// Fails without a route of the family, e.g., without IPv6 connectivity
+			if lookup, err := s.routingService.LookupRoute(probe); err == nil {
+				primaryRoutes[family] = lookup.Route
+			}
+		}
+	}
+	vendors := GetVendorService()
+	vendorOf := func(mac string) string { return vendors.LookupVendor(mac).Vendor }
+	return DefaultGateways(routes, neighbors, primaryRoutes, vendorOf), nil
+}
+
+// GetDNSConfig returns the resolver configuration: resolv.conf, the effective resolvers per
+// interface with their DNSSEC and DNS-over-TLS settings, nsswitch.conf and /etc/hosts.
+func (s *NetworkDashboardService) GetDNSConfig() (*DNSConfig, error) {
+	return s.dnsConfigService.GetDNSConfig()
+}
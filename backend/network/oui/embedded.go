@@ -0,0 +1,76 @@
+package oui
+
+//go:generate go run gen_registry.go
+
+import (
+	"compress/gzip"
+	"embed"
+	"io"
+	"log"
+	"path"
+	"strings"
+	"sync"
+)
+
+// registryFiles holds the registry data compiled into the binary. The complete IEEE registries
+// (oui.csv.gz, mam.csv.gz, oui36.csv.gz) are only present after gen_registry.go has downloaded
+// them; until then seed.csv, a small extract of MA-L assignments, is all there is.
+//
+//go:embed data
+var registryFiles embed.FS
+
+const seedFile = "seed.csv"
+
+var (
+	defaultRegistry *Registry
+	defaultOnce     sync.Once
+)
+
+// Default returns the shared registry, initialized with the generated IEEE registries or, if
+// they have not been generated, with the seed. Registries imported at runtime are added by the caller.
+func Default() *Registry {
+	defaultOnce.Do(func() {
+		defaultRegistry = NewRegistry()
+		entries, err := registryFiles.ReadDir("data")
+		if err != nil {
+			log.Printf("WARN: Could not read embedded OUI registry: %v", err)
+			return
+		}
+		for _, entry := range entries {
+			if entry.Name() == seedFile || !strings.HasSuffix(entry.Name(), ".csv.gz") {
+				continue
+			}
+			if err := loadEmbeddedFile(defaultRegistry, entry.Name()); err != nil {
+				log.Printf("WARN: Could not load embedded OUI registry %s: %v", entry.Name(), err)
+			}
+		}
+		if defaultRegistry.Size() == 0 {
+			log.Printf("WARN: IEEE OUI registries not generated, vendor lookup is limited to the seed list")
+			if err := loadEmbeddedFile(defaultRegistry, seedFile); err != nil {
+				log.Printf("WARN: Could not load embedded OUI registry %s: %v", seedFile, err)
+			}
+		}
+	})
+	return defaultRegistry
+}
+
+// loadEmbeddedFile merges an embedded registry file, gzip-compressed if its name ends in ".gz".
+func loadEmbeddedFile(registry *Registry, name string) error {
+	file, err := registryFiles.Open(path.Join("data", name))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if strings.HasSuffix(name, ".gz") {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		reader = gz
+	}
+	_, err = registry.LoadCSV(reader)
+	return err
+}
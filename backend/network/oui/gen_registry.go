@@ -0,0 +1,74 @@
+//go:build ignore
+
+// gen_registry downloads the IEEE MA-L, MA-M and MA-S registries and stores them
+// gzip-compressed in data/ for embedding. Run it with "go generate ./backend/network/oui".
+package main
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+var registries = []struct {
+	url  string
+	file string
+}{
+	{"https://standards-oui.ieee.org/oui/oui.csv", "oui.csv.gz"},
+	{"https://standards-oui.ieee.org/oui28/mam.csv", "mam.csv.gz"},
+	{"https://standards-oui.ieee.org/oui36/oui36.csv", "oui36.csv.gz"},
+}
+
+func main() {
+	client := &http.Client{Timeout: 5 * time.Minute}
+	for _, registry := range registries {
+		if err := download(client, registry.url, filepath.Join("data", registry.file)); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+func download(client *http.Client, url string, target string) error {
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	// The IEEE server rejects requests without a browser-like user agent.
+	request.Header.Set("User-Agent", "Mozilla/5.0 (compatible; privacy-buddy registry generator)")
+	response, err := client.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download %s: %s", url, response.Status)
+	}
+
+	tmpPath := target + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	gz, _ := gzip.NewWriterLevel(file, gzip.BestCompression)
+	n, err := io.Copy(gz, response.Body)
+	if closeErr := gz.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write %s: %w", target, err)
+	}
+	if err := os.Rename(tmpPath, target); err != nil {
+		return err
+	}
+	log.Printf("Stored %s (%d bytes uncompressed)", target, n)
+	return nil
+}
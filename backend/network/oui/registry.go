@@ -0,0 +1,162 @@
+package oui
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+)
+
+// Prefix lengths in bits of the IEEE assignment blocks.
+const (
+	prefixBitsMAL = 24 // MA-L (OUI)
+	prefixBitsMAM = 28 // MA-M
+	prefixBitsMAS = 36 // MA-S (OUI-36)
+)
+
+// registryFileNames are the names of the IEEE CSV downloads by prefix length.
+var registryFileNames = map[int]string{
+	prefixBitsMAL: "oui.csv",
+	prefixBitsMAM: "mam.csv",
+	prefixBitsMAS: "oui36.csv",
+}
+
+// assignment is a registry line: the hex prefix and the organization it is assigned to.
+type assignment struct {
+	prefix       string
+	organization string
+}
+
+// Registry maps IEEE MA-L, MA-M and MA-S assignments to organization names.
+type Registry struct {
+	mu       sync.RWMutex
+	prefixes map[int]map[string]string // Prefix length in bits -> hex prefix -> organization
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		prefixes: map[int]map[string]string{
+			prefixBitsMAL: {},
+			prefixBitsMAM: {},
+			prefixBitsMAS: {},
+		},
+	}
+}
+
+// LoadCSV merges assignments in the IEEE CSV format (oui.csv, mam.csv, oui36.csv) into the registry
+// and returns the number of assignments read. Lines starting with '#' are ignored.
+func (r *Registry) LoadCSV(reader io.Reader) (int, error) {
+	assignments, err := readCSV(reader)
+	if err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, a := range assignments {
+		r.prefixes[len(a.prefix)*4][a.prefix] = a.organization
+	}
+	return len(assignments), nil
+}
+
+// RegistryFileName returns the IEEE file name (oui.csv, mam.csv or oui36.csv) of the registry
+// the assignments in the CSV belong to. Files without or with mixed assignments are rejected.
+func RegistryFileName(reader io.Reader) (string, error) {
+	assignments, err := readCSV(reader)
+	if err != nil {
+		return "", err
+	}
+	if len(assignments) == 0 {
+		return "", fmt.Errorf("no assignments found in OUI registry")
+	}
+	bits := len(assignments[0].prefix) * 4
+	for _, a := range assignments[1:] {
+		if len(a.prefix)*4 != bits {
+			return "", fmt.Errorf("OUI registry mixes MA-L, MA-M and MA-S assignments")
+		}
+	}
+	return registryFileNames[bits], nil
+}
+
+// readCSV parses the assignments of an IEEE CSV file, skipping the header, malformed lines
+// and prefixes of unknown length.
+func readCSV(reader io.Reader) ([]assignment, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.Comment = '#'
+	csvReader.FieldsPerRecord = -1
+	csvReader.LazyQuotes = true
+
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse OUI registry: %w", err)
+	}
+
+	var assignments []assignment
+	for _, record := range records {
+		if len(record) < 3 || strings.EqualFold(record[0], "Registry") {
+			continue // Header or malformed line
+		}
+		prefix := strings.ToUpper(strings.TrimSpace(record[1]))
+		if _, ok := registryFileNames[len(prefix)*4]; !ok || !isHex(prefix) {
+			continue
+		}
+		assignments = append(assignments, assignment{prefix: prefix, organization: strings.TrimSpace(record[2])})
+	}
+	return assignments, nil
+}
+
+// Lookup returns the organization the MAC address is assigned to, preferring the
+// most specific (MA-S, then MA-M, then MA-L) assignment.
+func (r *Registry) Lookup(mac net.HardwareAddr) string {
+	if len(mac) < 3 {
+		return ""
+	}
+	hexMAC := strings.ToUpper(fmt.Sprintf("%x", []byte(mac)))
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, bits := range []int{prefixBitsMAS, prefixBitsMAM, prefixBitsMAL} {
+		digits := bits / 4
+		if len(hexMAC) < digits {
+			continue
+		}
+		if org, ok := r.prefixes[bits][hexMAC[:digits]]; ok {
+			return org
+		}
+	}
+	return ""
+}
+
+// Size returns the number of known assignments.
+func (r *Registry) Size() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	size := 0
+	for _, table := range r.prefixes {
+		size += len(table)
+	}
+	return size
+}
+
+// IsLocallyAdministered reports whether the U/L bit of the MAC address is set. Such addresses
+// are not assigned by the IEEE and are typically randomized (private Wi-Fi addresses, VMs, containers).
+func IsLocallyAdministered(mac net.HardwareAddr) bool {
+	return len(mac) > 0 && mac[0]&0x02 != 0
+}
+
+// IsMulticast reports whether the I/G bit of the MAC address is set.
+func IsMulticast(mac net.HardwareAddr) bool {
+	return len(mac) > 0 && mac[0]&0x01 != 0
+}
+
+func isHex(s string) bool {
+	for _, c := range s {
+		if !(c >= '0' && c <= '9' || c >= 'A' && c <= 'F') {
+			return false
+		}
+	}
+	return s != ""
+}
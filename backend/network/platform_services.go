@@ -0,0 +1,53 @@
+package network
+
+// PlatformServices bundles the operating system specific service implementations.
+// They are built by the platform/network package and injected in main.go.
+type PlatformServices struct {
+	ARPCache          ARPCacheService
+	NetworkInterfaces NetworkInterfaceService
+	Connections       NetworkConnectionService
+	PacketCapture     PacketCaptureService
+	LocalSockets      LocalSocketService
+	InterfaceCounters InterfaceCounterService
+	Wireless          WirelessService
+	Routing           RoutingService
+	DNSConfig         DNSConfigService
+	ChangeMonitor     NetworkChangeMonitor
+}
+
+// WithDefaults returns a copy where every missing implementation is replaced
+// by its Unsupported* counterpart, so callers never have to check for nil.
+// Interface counters fall back to gopsutil, which works on all platforms.
+func (p PlatformServices) WithDefaults() PlatformServices {
+	if p.ARPCache == nil {
+		p.ARPCache = UnsupportedARPCacheService{}
+	}
+	if p.NetworkInterfaces == nil {
+		p.NetworkInterfaces = UnsupportedNetworkInterfaceService{}
+	}
+	if p.Connections == nil {
+		p.Connections = UnsupportedNetworkConnectionService{}
+	}
+	if p.PacketCapture == nil {
+		p.PacketCapture = UnsupportedPacketCaptureService{}
+	}
+	if p.LocalSockets == nil {
+		p.LocalSockets = UnsupportedLocalSocketService{}
+	}
+	if p.InterfaceCounters == nil {
+		p.InterfaceCounters = GopsutilInterfaceCounterService{}
+	}
+	if p.Wireless == nil {
+		p.Wireless = UnsupportedWirelessService{}
+	}
+	if p.Routing == nil {
+		p.Routing = UnsupportedRoutingService{}
+	}
+	if p.DNSConfig == nil {
+		p.DNSConfig = UnsupportedDNSConfigService{}
+	}
+	if p.ChangeMonitor == nil {
+		p.ChangeMonitor = UnsupportedNetworkChangeMonitor{}
+	}
+	return p
+}
@@ -0,0 +1,195 @@
+package network
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+const (
+	processContextIdleTTL  = 10 * time.Minute // Unused entries are pruned after this time
+	processParentMaxDepth  = 16
+	processContextPruneGap = 1 * time.Minute
+)
+
+// Singleton instance (thread-safe)
+var (
+	processContextInstance *ProcessContextService
+	processContextOnce     sync.Once
+)
+
+type processContextEntry struct {
+	createTime int64          // Distinguishes reused PIDs
+	context    ProcessContext // SHA256 is set once the executable was hashed
+	ppid       int32
+	lastUsed   time.Time
+}
+
+// ProcessContextService collects executable path, command line, user, start time and parent
+// chain of processes lazily and caches them by PID and start time. Executable hashes are
+// computed on request from the image the process runs and cached in the same entry, so a
+// binary replaced on disk after the start does not change the hash.
+type ProcessContextService struct {
+	mu        sync.Mutex
+	entries   map[int32]*processContextEntry
+	lastPrune time.Time
+}
+
+// GetProcessContextService returns the shared ProcessContextService instance.
+func GetProcessContextService() *ProcessContextService {
+	processContextOnce.Do(func() {
+		processContextInstance = &ProcessContextService{
+			entries: make(map[int32]*processContextEntry),
+		}
+	})
+	return processContextInstance
+}
+
+// GetProcessContext returns the full context of a process including the SHA-256 of its executable.
+func (s *ProcessContextService) GetProcessContext(pid int32) (*ProcessContext, error) {
+	ctx, err := s.lookup(pid)
+	if err != nil {
+		return nil, err
+	}
+	if ctx.SHA256 == "" && ctx.ExecutablePath != "" {
+		sum, err := s.executableSHA256(pid, ctx.ExecutablePath)
+		if err == nil {
+			ctx.SHA256 = sum
+		}
+	}
+	return ctx, nil
+}
+
+// Lookup returns the cached context of a process without hashing its executable.
+// The SHA-256 is only included if it was computed before. It returns nil if the
+// process does not exist (anymore).
+func (s *ProcessContextService) Lookup(pid int32) *ProcessContext {
+	ctx, err := s.lookup(pid)
+	if err != nil {
+		return nil
+	}
+	return ctx
+}
+
+func (s *ProcessContextService) lookup(pid int32) (*ProcessContext, error) {
+	if pid <= 0 {
+		return nil, fmt.Errorf("invalid PID %d", pid)
+	}
+	entry, err := s.entry(pid)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	ctx := entry.context
+	s.mu.Unlock()
+	ctx.Parents = nil
+	ppid := entry.ppid
+	visited := map[int32]struct{}{pid: {}}
+	for depth := 0; depth < processParentMaxDepth && ppid > 0; depth++ {
+		if _, loop := visited[ppid]; loop {
+			break
+		}
+		visited[ppid] = struct{}{}
+		parent, err := s.entry(ppid)
+		if err != nil {
+			break
+		}
+		ctx.Parents = append(ctx.Parents, ProcessAncestor{
+			PID:            parent.context.PID,
+			Name:           parent.context.Name,
+			ExecutablePath: parent.context.ExecutablePath,
+		})
+		ppid = parent.ppid
+	}
+	return &ctx, nil
+}
+
+// entry returns the cache entry of a process, collecting it if the PID is new or was reused.
+func (s *ProcessContextService) entry(pid int32) (*processContextEntry, error) {
+	proc, err := process.NewProcess(pid)
+	if err != nil {
+		return nil, err
+	}
+	createTime, err := proc.CreateTime()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get start time of process %d: %w", pid, err)
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	s.pruneLocked(now)
+	if entry, ok := s.entries[pid]; ok && entry.createTime == createTime {
+		entry.lastUsed = now
+		s.mu.Unlock()
+		return entry, nil
+	}
+	s.mu.Unlock()
+
+	// Fields that are not accessible (e.g., processes of other users) stay empty.
+	entry := &processContextEntry{createTime: createTime, lastUsed: now}
+	entry.context = ProcessContext{
+		PID:       pid,
+		StartTime: time.UnixMilli(createTime).Format(time.RFC3339),
+	}
+	entry.context.Name, _ = proc.Name()
+	entry.context.ExecutablePath, _ = proc.Exe()
+	entry.context.Cmdline, _ = proc.Cmdline()
+	entry.context.Username, _ = proc.Username()
+	entry.ppid, _ = proc.Ppid()
+
+	s.mu.Lock()
+	s.entries[pid] = entry
+	s.mu.Unlock()
+	return entry, nil
+}
+
+// executableSHA256 hashes the executable a process runs and stores the result in its cache entry.
+func (s *ProcessContextService) executableSHA256(pid int32, path string) (string, error) {
+	entry, err := s.entry(pid)
+	if err != nil {
+		return "", err
+	}
+
+	imagePath := processImagePath(pid, strings.TrimSuffix(path, " (deleted)"))
+	file, err := os.Open(imagePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("failed to hash %s: %w", imagePath, err)
+	}
+	sum := hex.EncodeToString(hash.Sum(nil))
+
+	// The PID may have been reused while hashing; the hash would then belong to another process.
+	current, err := s.entry(pid)
+	if err != nil || current.createTime != entry.createTime {
+		return "", fmt.Errorf("process %d exited while hashing its executable", pid)
+	}
+	s.mu.Lock()
+	current.context.SHA256 = sum
+	s.mu.Unlock()
+	return sum, nil
+}
+
+// pruneLocked drops entries that have not been used for processContextIdleTTL. The caller must hold s.mu.
+func (s *ProcessContextService) pruneLocked(now time.Time) {
+	if now.Sub(s.lastPrune) < processContextPruneGap {
+		return
+	}
+	s.lastPrune = now
+	for pid, entry := range s.entries {
+		if now.Sub(entry.lastUsed) > processContextIdleTTL {
+			delete(s.entries, pid)
+		}
+	}
+}
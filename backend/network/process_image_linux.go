@@ -0,0 +1,11 @@
+//go:build linux
+
+package network
+
+import "fmt"
+
+// processImagePath returns the file to hash for the executable of a process. /proc/<pid>/exe
+// opens the image the process was started from, even if the file was replaced or deleted since.
+func processImagePath(pid int32, _ string) string {
+	return fmt.Sprintf("/proc/%d/exe", pid)
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package network
+
+// processImagePath returns the file to hash for the executable of a process. Without a handle
+// to the running image, this is the file at the executable path.
+func processImagePath(_ int32, path string) string {
+	return path
+}
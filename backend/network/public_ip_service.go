@@ -34,3 +34,9 @@ func (i *PublicIPService) GetPublicIPInfo() (*PublicIPInfo, error) {
 	// The platform-specific implementation will be in get_default_interface_*.go files
 	return getPublicIPInfo()
 }
+
+// DefaultGatewayIP returns the IPv4 address of the default gateway.
+// This function relies on platform-specific implementations.
+func DefaultGatewayIP() (string, error) {
+	return getDefaultGatewayIP()
+}
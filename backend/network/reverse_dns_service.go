@@ -0,0 +1,288 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	reverseDNSTimeout        = 3 * time.Second
+	reverseDNSTTL            = 10 * time.Minute
+	reverseDNSNegativeTTL    = 1 * time.Minute
+	reverseDNSMaxConcurrency = 8
+	reverseDNSMaxEntries     = 10000 // Cache size; the entries expiring first are evicted beyond it
+	reverseDNSQueueSize      = 1024  // Background lookups waiting for a worker; more are dropped
+
+	// reverseDNSResolverEnv overrides the resolver address at startup, e.g. "127.0.0.1:5353".
+	reverseDNSResolverEnv = "PRIVACY_BUDDY_DNS_RESOLVER"
+)
+
+// errResolverChanged is returned by lookups that were started before the resolver was replaced.
+var errResolverChanged = errors.New("resolver changed during the lookup")
+
+// Singleton instance (thread-safe)
+var (
+	reverseDNSInstance *ReverseDNSService
+	reverseDNSOnce     sync.Once
+)
+
+// ReverseDNSResult is emitted as "reverseDNSResolved" event once a PTR lookup has finished.
+type ReverseDNSResult struct {
+	IP       string `json:"IP"`
+	Hostname string `json:"Hostname"`
+}
+
+type reverseDNSEntry struct {
+	hostname string // Empty for negative cache entries
+	expires  time.Time
+}
+
+// ReverseDNSService is a shared PTR lookup cache with positive and negative TTLs.
+// Lookups run with limited concurrency against the system or a configured resolver.
+type ReverseDNSService struct {
+	appCtx context.Context
+
+	mu              sync.Mutex
+	resolver        *net.Resolver
+	resolverAddress string
+	generation      uint64 // Incremented when the resolver is replaced
+	entries         map[string]reverseDNSEntry
+	pending         map[string]struct{}
+	semaphore       chan struct{}
+	queue           chan string // IPs for the background workers started by GetReverseDNSService
+}
+
+// GetReverseDNSService returns the shared ReverseDNSService instance.
+func GetReverseDNSService() *ReverseDNSService {
+	reverseDNSOnce.Do(func() {
+		reverseDNSInstance = &ReverseDNSService{
+			resolver:  net.DefaultResolver,
+			entries:   make(map[string]reverseDNSEntry),
+			pending:   make(map[string]struct{}),
+			semaphore: make(chan struct{}, reverseDNSMaxConcurrency),
+			queue:     make(chan string, reverseDNSQueueSize),
+		}
+		for i := 0; i < reverseDNSMaxConcurrency; i++ {
+			go reverseDNSInstance.worker()
+		}
+		if address := os.Getenv(reverseDNSResolverEnv); address != "" {
+			if err := reverseDNSInstance.SetResolverAddress(address); err != nil {
+				log.Printf("WARN: Ignoring %s: %v", reverseDNSResolverEnv, err)
+			}
+		}
+	})
+	return reverseDNSInstance
+}
+
+// WailsInit stores the application context used to emit lookup results.
+func (s *ReverseDNSService) WailsInit(ctx context.Context) {
+	s.appCtx = ctx
+}
+
+// SetResolverAddress sets the DNS server used for PTR lookups ("host" or "host:port").
+// An empty address switches back to the system resolver. The cache is cleared and the results
+// of lookups still running against the previous resolver are discarded.
+func (s *ReverseDNSService) SetResolverAddress(address string) error {
+	resolver := net.DefaultResolver
+	if address != "" {
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			address = net.JoinHostPort(address, "53")
+		}
+		host, _, _ := net.SplitHostPort(address)
+		if net.ParseIP(host) == nil {
+			return fmt.Errorf("invalid resolver address '%s': expected an IP address", address)
+		}
+		dialAddress := address
+		resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, network, dialAddress)
+			},
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.resolver = resolver
+	s.resolverAddress = address
+	s.generation++
+	s.entries = make(map[string]reverseDNSEntry)
+	s.pending = make(map[string]struct{})
+	return nil
+}
+
+// GetResolverAddress returns the configured resolver address, or an empty string for the system resolver.
+func (s *ReverseDNSService) GetResolverAddress() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.resolverAddress
+}
+
+// Hostname returns the cached hostname of the IP without blocking. On a cache miss
+// a lookup is started in the background and its result is emitted as an event.
+func (s *ReverseDNSService) Hostname(ip string) string {
+	if !isResolvableIP(ip) {
+		return ""
+	}
+
+	s.mu.Lock()
+	entry, ok := s.entries[ip]
+	if ok && time.Now().Before(entry.expires) {
+		s.mu.Unlock()
+		return entry.hostname
+	}
+	if _, running := s.pending[ip]; running {
+		s.mu.Unlock()
+		return entry.hostname
+	}
+	select {
+	case s.queue <- ip:
+		s.pending[ip] = struct{}{}
+	default:
+		// Queue full; a later call retries
+	}
+	s.mu.Unlock()
+	return entry.hostname
+}
+
+// worker resolves the IPs queued by Hostname and emits the results.
+func (s *ReverseDNSService) worker() {
+	for ip := range s.queue {
+		hostname, _ := s.lookup(ip)
+		if hostname != "" && s.appCtx != nil {
+			runtime.EventsEmit(s.appCtx, "reverseDNSResolved", ReverseDNSResult{IP: ip, Hostname: hostname})
+		}
+	}
+}
+
+// LookupAddr resolves the hostname of the IP, answering from the cache if possible.
+func (s *ReverseDNSService) LookupAddr(ip string) (string, error) {
+	if !isResolvableIP(ip) {
+		return "", fmt.Errorf("invalid IP address '%s'", ip)
+	}
+
+	s.mu.Lock()
+	entry, ok := s.entries[ip]
+	s.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.hostname, nil
+	}
+	return s.lookup(ip)
+}
+
+// LookupAll resolves the hostnames of all IPs with reverseDNSMaxConcurrency workers.
+// IPs without a PTR record are omitted.
+func (s *ReverseDNSService) LookupAll(ips []string) map[string]string {
+	results := make(map[string]string)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	work := make(chan string)
+	for i := 0; i < reverseDNSMaxConcurrency && i < len(ips); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ip := range work {
+				if hostname, err := s.LookupAddr(ip); err == nil && hostname != "" {
+					mu.Lock()
+					results[ip] = hostname
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for _, ip := range ips {
+		work <- ip
+	}
+	close(work)
+	wg.Wait()
+	return results
+}
+
+// ClearCache discards all cached lookup results.
+func (s *ReverseDNSService) ClearCache() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = make(map[string]reverseDNSEntry)
+}
+
+// lookup performs the PTR lookup and stores the result in the cache. The result is dropped
+// if the resolver was replaced in the meantime.
+func (s *ReverseDNSService) lookup(ip string) (string, error) {
+	s.semaphore <- struct{}{}
+	defer func() { <-s.semaphore }()
+
+	s.mu.Lock()
+	resolver, generation := s.resolver, s.generation
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), reverseDNSTimeout)
+	defer cancel()
+	names, err := resolver.LookupAddr(ctx, ip)
+
+	entry := reverseDNSEntry{expires: time.Now().Add(reverseDNSNegativeTTL)}
+	if err == nil && len(names) > 0 {
+		entry = reverseDNSEntry{
+			hostname: strings.TrimSuffix(names[0], "."),
+			expires:  time.Now().Add(reverseDNSTTL),
+		}
+	}
+
+	s.mu.Lock()
+	if generation != s.generation {
+		// The pending marker belongs to the current resolver, if any
+		s.mu.Unlock()
+		return "", errResolverChanged
+	}
+	if _, ok := s.entries[ip]; !ok && len(s.entries) >= reverseDNSMaxEntries {
+		s.evictLocked()
+	}
+	s.entries[ip] = entry
+	delete(s.pending, ip)
+	s.mu.Unlock()
+
+	if err != nil {
+		return "", err
+	}
+	return entry.hostname, nil
+}
+
+// evictLocked removes the expired entries and, if the cache is still full, the tenth of
+// the entries that expire first.
+func (s *ReverseDNSService) evictLocked() {
+	now := time.Now()
+	for ip, entry := range s.entries {
+		if now.After(entry.expires) {
+			delete(s.entries, ip)
+		}
+	}
+	if len(s.entries) < reverseDNSMaxEntries {
+		return
+	}
+	ips := make([]string, 0, len(s.entries))
+	for ip := range s.entries {
+		ips = append(ips, ip)
+	}
+	sort.Slice(ips, func(i, j int) bool {
+		return s.entries[ips[i]].expires.Before(s.entries[ips[j]].expires)
+	})
+	for _, ip := range ips[:len(ips)/10+1] {
+		delete(s.entries, ip)
+	}
+}
+
+// isResolvableIP reports whether a PTR lookup makes sense for the IP.
+func isResolvableIP(ip string) bool {
+	parsed := net.ParseIP(ip)
+	return parsed != nil && !parsed.IsUnspecified() && !parsed.IsMulticast()
+}
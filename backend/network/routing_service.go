@@ -0,0 +1,8 @@
+package network
+
+// RoutingService defines the interface for reading the routing tables and policy rules.
+type RoutingService interface {
+	GetRoutes() ([]Route, error)
+	GetRoutingRules() ([]RoutingRule, error)
+	LookupRoute(destination string) (*RouteLookup, error)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net"
 	"os"
 	"path/filepath"
 	"strings"
@@ -44,9 +45,18 @@ func (s *AdvancedNetworkToolsService) WailsInit(ctx context.Context) {
 	s.appCtx = ctx
 }
 
+// SetTrackerService sets the service that DNS queries and TLS SNI names seen in captures are
+// matched against. The socket table of connectionService attributes them to processes.
+func (s *AdvancedNetworkToolsService) SetTrackerService(trackerSvc *anynetwork.TrackerService, connectionService anynetwork.NetworkConnectionService) {
+	s.trackerSvc = trackerSvc
+	s.sockets = newSocketTable(connectionService)
+}
+
 // AdvancedNetworkToolsService provides advanced network diagnostic functionalities.
 type AdvancedNetworkToolsService struct {
-	appCtx context.Context
+	appCtx     context.Context
+	trackerSvc *anynetwork.TrackerService
+	sockets    *socketTable // Owners of the local endpoints of captured packets
 
 	// Packet capture state
 	captureMutex sync.Mutex
@@ -134,6 +144,10 @@ func (s *AdvancedNetworkToolsService) StartPacketCapture(iface string, bpfFilter
 		return fmt.Errorf("error setting BPF filter: %w", err)
 	}
 
+	if s.sockets != nil {
+		s.sockets.refresh()
+	}
+
 	var captureCtx context.Context
 	captureCtx, s.stopCapture = context.WithCancel(s.appCtx)
 	s.isCapturing = true
@@ -189,7 +203,13 @@ func (s *AdvancedNetworkToolsService) processPacket(packet gopacket.Packet) anyn
 	summaryParts := []string{}
 
 	if ethLayer := packet.Layer(layers.LayerTypeEthernet); ethLayer != nil {
-		summaryParts = append(summaryParts, fmt.Sprintf("Eth %s->%s", ethLayer.(*layers.Ethernet).SrcMAC, ethLayer.(*layers.Ethernet).DstMAC))
+		eth := ethLayer.(*layers.Ethernet)
+		vendors := anynetwork.GetVendorService()
+		cp.SourceMAC = eth.SrcMAC.String()
+		cp.DestinationMAC = eth.DstMAC.String()
+		cp.SourceVendor = vendors.LookupVendor(cp.SourceMAC).Vendor
+		cp.DestinationVendor = vendors.LookupVendor(cp.DestinationMAC).Vendor
+		summaryParts = append(summaryParts, fmt.Sprintf("Eth %s->%s", formatMACWithVendor(cp.SourceMAC, cp.SourceVendor), formatMACWithVendor(cp.DestinationMAC, cp.DestinationVendor)))
 	}
 
 	if ipLayer := packet.Layer(layers.LayerTypeIPv4); ipLayer != nil {
@@ -247,10 +267,70 @@ func (s *AdvancedNetworkToolsService) processPacket(packet gopacket.Packet) anyn
 		summaryParts = append(summaryParts, fmt.Sprintf("ICMPv6 Type:%d Code:%d", icmpv6.TypeCode.Type(), icmpv6.TypeCode.Code()))
 	}
 
+	if dnsLayer := packet.Layer(layers.LayerTypeDNS); dnsLayer != nil {
+		if dns := dnsLayer.(*layers.DNS); !dns.QR {
+			for _, q := range dns.Questions {
+				summaryParts = append(summaryParts, fmt.Sprintf("DNS Query %s %s", q.Name, q.Type))
+				s.recordTrackerDomain(packet, string(q.Name), "dns")
+			}
+		}
+	}
+
+	if tcpLayer := packet.Layer(layers.LayerTypeTCP); tcpLayer != nil {
+		if sni := extractSNI(tcpLayer.(*layers.TCP).Payload); sni != "" {
+			summaryParts = append(summaryParts, fmt.Sprintf("TLS SNI %s", sni))
+			s.recordTrackerDomain(packet, sni, "sni")
+		}
+	}
+
+	s.recordDevice(packet, cp)
+
+	rdns := anynetwork.GetReverseDNSService()
+	cp.SourceHostname = rdns.Hostname(cp.Source)
+	cp.DestinationHostname = rdns.Hostname(cp.Destination)
+
 	cp.Summary = strings.Join(summaryParts, " ")
 	return cp
 }
 
+// formatMACWithVendor appends the vendor name to a MAC address, if known.
+func formatMACWithVendor(mac string, vendor string) string {
+	if vendor == "" {
+		return mac
+	}
+	return fmt.Sprintf("%s (%s)", mac, vendor)
+}
+
+// recordDevice passes the sender of a captured frame to the device inventory. ARP packets
+// carry the sender's own IP; for IP packets only on-link source addresses are attributed.
+func (s *AdvancedNetworkToolsService) recordDevice(packet gopacket.Packet, cp anynetwork.CapturedPacket) {
+	inventory := anynetwork.GetDeviceInventoryService()
+	if arpLayer := packet.Layer(layers.LayerTypeARP); arpLayer != nil {
+		arp := arpLayer.(*layers.ARP)
+		inventory.ObserveDevice(net.HardwareAddr(arp.SourceHwAddress).String(), net.IP(arp.SourceProtAddress).String(), "capture")
+		return
+	}
+	if cp.SourceMAC != "" && cp.Source != "" {
+		inventory.ObserveCapturedFrame(cp.SourceMAC, cp.Source)
+	}
+}
+
+// recordTrackerDomain passes a domain seen in a capture to the tracker service, if one is set,
+// together with the process owning the local endpoint of the packet.
+func (s *AdvancedNetworkToolsService) recordTrackerDomain(packet gopacket.Packet, domain string, source string) {
+	if s.trackerSvc == nil {
+		return
+	}
+	var pid int32
+	var processName string
+	if flow, ok := s.sockets.flow(packet); ok {
+		if owner := s.sockets.owner(flow); owner.pid != 0 {
+			pid, processName = owner.pid, owner.name
+		}
+	}
+	s.trackerSvc.RecordDomain(domain, source, pid, processName)
+}
+
 // GetCaptureTemplates returns all available templates.
 func (s *AdvancedNetworkToolsService) GetCaptureTemplates() []anynetwork.CaptureTemplate {
 	predefined := []anynetwork.CaptureTemplate{
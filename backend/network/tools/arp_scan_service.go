@@ -0,0 +1,386 @@
+package tools
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"log"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	anynetwork "privacy-buddy/backend/network"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcap"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	arpScanDefaultRate  = 100 // ARP requests per second
+	arpScanMaxRate      = 10000
+	arpScanMaxHosts     = 4096
+	arpScanReplyTimeout = 2 * time.Second // Wait time for late replies after the last request
+	arpScanProgressStep = 16
+)
+
+// ARPScanDevice is a device that answered an ARP request during a scan.
+type ARPScanDevice struct {
+	IPAddress           string  `json:"ipAddress"`
+	MACAddress          string  `json:"macAddress"`
+	Vendor              string  `json:"vendor"`
+	LocallyAdministered bool    `json:"locallyAdministered"`
+	ResponseTimeMs      float64 `json:"responseTimeMs"`
+}
+
+// ARPScanProgress is emitted as "arpScanProgress" event while a scan is running.
+type ARPScanProgress struct {
+	Interface string `json:"interface"`
+	Subnet    string `json:"subnet"`
+	Sent      int    `json:"sent"`
+	Total     int    `json:"total"`
+	Found     int    `json:"found"`
+	Done      bool   `json:"done"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ARPScanService discovers devices in the local subnet by sending ARP requests to every host.
+type ARPScanService struct {
+	appCtx context.Context
+
+	mu       sync.Mutex
+	stopScan context.CancelFunc
+	devices  map[string]ARPScanDevice
+}
+
+// NewARPScanService creates a new ARPScanService.
+func NewARPScanService() *ARPScanService {
+	return &ARPScanService{devices: make(map[string]ARPScanDevice)}
+}
+
+// WailsInit stores the application context used to emit scan events.
+func (s *ARPScanService) WailsInit(ctx context.Context) {
+	s.appCtx = ctx
+}
+
+// StartARPScan sends ARP requests to all hosts of the IPv4 subnet of the given pcap device.
+// packetsPerSecond limits the request rate (0 uses the default). Progress is reported via
+// "arpScanProgress" events, every answering device via an "arpScanDevice" event.
+func (s *ARPScanService) StartARPScan(iface string, packetsPerSecond int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopScan != nil {
+		return fmt.Errorf("an ARP scan is already in progress")
+	}
+
+	srcIP, subnet, srcMAC, err := scanSourceForDevice(iface)
+	if err != nil {
+		return err
+	}
+	targets, err := subnetHosts(subnet, srcIP)
+	if err != nil {
+		return err
+	}
+	if packetsPerSecond <= 0 {
+		packetsPerSecond = arpScanDefaultRate
+	}
+	if packetsPerSecond > arpScanMaxRate {
+		return fmt.Errorf("rate of %d requests/s exceeds the maximum of %d", packetsPerSecond, arpScanMaxRate)
+	}
+
+	handle, err := pcap.OpenLive(iface, 128, false, 100*time.Millisecond)
+	if err != nil {
+		return fmt.Errorf("error opening device %s: %w", iface, err)
+	}
+	if err := handle.SetBPFFilter("arp"); err != nil {
+		handle.Close()
+		return fmt.Errorf("error setting BPF filter: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stopScan = cancel
+	s.devices = make(map[string]ARPScanDevice)
+
+	progress := ARPScanProgress{Interface: iface, Subnet: subnet.String(), Total: len(targets)}
+	log.Printf("Starting ARP scan of %s on %s (%d hosts, %d requests/s)", subnet, iface, len(targets), packetsPerSecond)
+	go s.runScan(ctx, handle, srcIP, srcMAC, targets, packetsPerSecond, progress)
+	return nil
+}
+
+// StopARPScan cancels a running scan.
+func (s *ARPScanService) StopARPScan() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopScan != nil {
+		s.stopScan()
+	}
+}
+
+// GetARPScanResults returns the devices found by the current or last scan, sorted by IP.
+func (s *ARPScanService) GetARPScanResults() []ARPScanDevice {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	devices := make([]ARPScanDevice, 0, len(s.devices))
+	for _, device := range s.devices {
+		devices = append(devices, device)
+	}
+	sort.Slice(devices, func(i, j int) bool {
+		return ipToUint32(net.ParseIP(devices[i].IPAddress)) < ipToUint32(net.ParseIP(devices[j].IPAddress))
+	})
+	return devices
+}
+
+func (s *ARPScanService) runScan(ctx context.Context, handle *pcap.Handle, srcIP net.IP, srcMAC net.HardwareAddr, targets []net.IP, rate int, progress ARPScanProgress) {
+	defer handle.Close()
+	defer func() {
+		s.mu.Lock()
+		s.stopScan = nil
+		progress.Found = len(s.devices)
+		s.mu.Unlock()
+		progress.Done = true
+		s.emit("arpScanProgress", progress)
+		s.emit("arpScanFinished", s.GetARPScanResults())
+	}()
+
+	var sentMu sync.Mutex
+	sentAt := make(map[string]time.Time, len(targets))
+
+	receiverDone := make(chan struct{})
+	receiveCtx, stopReceiving := context.WithCancel(ctx)
+	defer stopReceiving()
+	go func() {
+		defer close(receiverDone)
+		s.receiveReplies(receiveCtx, handle, srcIP, func(ip string) (time.Time, bool) {
+			sentMu.Lock()
+			defer sentMu.Unlock()
+			t, ok := sentAt[ip]
+			return t, ok
+		})
+	}()
+
+	ticker := time.NewTicker(time.Second / time.Duration(rate))
+	defer ticker.Stop()
+
+	for i, target := range targets {
+		select {
+		case <-ctx.Done():
+			log.Println("ARP scan cancelled.")
+			stopReceiving()
+			<-receiverDone
+			return
+		case <-ticker.C:
+		}
+
+		packet, err := buildARPRequest(srcMAC, srcIP, target)
+		if err == nil {
+			sentMu.Lock()
+			sentAt[target.String()] = time.Now()
+			sentMu.Unlock()
+			err = handle.WritePacketData(packet)
+		}
+		if err != nil {
+			log.Printf("ERROR: Failed to send ARP request to %s: %v", target, err)
+			progress.Error = err.Error()
+			stopReceiving()
+			<-receiverDone
+			return
+		}
+
+		progress.Sent = i + 1
+		if progress.Sent%arpScanProgressStep == 0 || progress.Sent == len(targets) {
+			s.mu.Lock()
+			progress.Found = len(s.devices)
+			s.mu.Unlock()
+			s.emit("arpScanProgress", progress)
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(arpScanReplyTimeout):
+	}
+	stopReceiving()
+	<-receiverDone
+	log.Printf("ARP scan finished: %d devices found.", len(s.GetARPScanResults()))
+}
+
+// receiveReplies records ARP replies addressed to srcIP until the context is cancelled.
+func (s *ARPScanService) receiveReplies(ctx context.Context, handle *pcap.Handle, srcIP net.IP, sentAt func(ip string) (time.Time, bool)) {
+	for ctx.Err() == nil {
+		data, ci, err := handle.ReadPacketData()
+		if err == pcap.NextErrorTimeoutExpired {
+			continue
+		}
+		if err != nil {
+			log.Printf("ARP scan receive loop stopped: %v", err)
+			return
+		}
+
+		packet := gopacket.NewPacket(data, layers.LayerTypeEthernet, gopacket.NoCopy)
+		arpLayer := packet.Layer(layers.LayerTypeARP)
+		if arpLayer == nil {
+			continue
+		}
+		arp := arpLayer.(*layers.ARP)
+		if arp.Operation != layers.ARPReply || !net.IP(arp.DstProtAddress).Equal(srcIP) {
+			continue
+		}
+
+		ip := net.IP(arp.SourceProtAddress).String()
+		sent, ok := sentAt(ip)
+		if !ok {
+			continue // Reply to a request we did not send
+		}
+		mac := net.HardwareAddr(arp.SourceHwAddress).String()
+		vendor := anynetwork.GetVendorService().LookupVendor(mac)
+		device := ARPScanDevice{
+			IPAddress:           ip,
+			MACAddress:          mac,
+			Vendor:              vendor.Vendor,
+			LocallyAdministered: vendor.LocallyAdministered,
+			ResponseTimeMs:      float64(ci.Timestamp.Sub(sent).Microseconds()) / 1000,
+		}
+
+		s.mu.Lock()
+		_, known := s.devices[ip]
+		if !known {
+			s.devices[ip] = device
+		}
+		s.mu.Unlock()
+		if !known {
+			anynetwork.GetDeviceInventoryService().ObserveDevice(mac, ip, "arp-scan")
+			s.emit("arpScanDevice", device)
+		}
+	}
+}
+
+func (s *ARPScanService) emit(eventName string, data interface{}) {
+	if s.appCtx != nil {
+		runtime.EventsEmit(s.appCtx, eventName, data)
+	}
+}
+
+// scanSourceForDevice returns the IPv4 address, subnet and MAC address used to scan from a pcap device.
+func scanSourceForDevice(deviceName string) (net.IP, *net.IPNet, net.HardwareAddr, error) {
+	devices, err := pcap.FindAllDevs()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error finding pcap devices: %w", err)
+	}
+
+	for _, dev := range devices {
+		if dev.Name != deviceName {
+			continue
+		}
+		for _, addr := range dev.Addresses {
+			ip := addr.IP.To4()
+			if ip == nil || ip.IsLoopback() || addr.Netmask == nil {
+				continue
+			}
+			mask := net.IPMask(addr.Netmask)
+			if len(mask) == net.IPv6len {
+				mask = mask[12:]
+			}
+			mac, err := hardwareAddrForIP(deviceName, ip)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			return ip, &net.IPNet{IP: ip.Mask(mask), Mask: mask}, mac, nil
+		}
+		return nil, nil, nil, fmt.Errorf("interface %s has no IPv4 address", deviceName)
+	}
+	return nil, nil, nil, fmt.Errorf("interface %s not found", deviceName)
+}
+
+// hardwareAddrForIP finds the MAC address of the interface by name or, if the pcap
+// name differs from the OS name (Windows), by its IP address.
+func hardwareAddrForIP(name string, ip net.IP) (net.HardwareAddr, error) {
+	if iface, err := net.InterfaceByName(name); err == nil && len(iface.HardwareAddr) == 6 {
+		return iface.HardwareAddr, nil
+	}
+
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return nil, fmt.Errorf("error finding net interfaces: %w", err)
+	}
+	for _, iface := range interfaces {
+		addrs, _ := iface.Addrs()
+		for _, addr := range addrs {
+			if ipnet, ok := addr.(*net.IPNet); ok && ipnet.IP.Equal(ip) && len(iface.HardwareAddr) == 6 {
+				return iface.HardwareAddr, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("interface %s has no Ethernet address", name)
+}
+
+// subnetHosts returns all host addresses of the subnet except the network, broadcast and own
+// address. A /31 has no network and broadcast address (RFC 3021), so only the peer is scanned.
+func subnetHosts(subnet *net.IPNet, own net.IP) ([]net.IP, error) {
+	ones, bits := subnet.Mask.Size()
+	if bits != 32 {
+		return nil, fmt.Errorf("subnet %s is not an IPv4 subnet", subnet)
+	}
+	if ones == 32 {
+		return nil, fmt.Errorf("subnet %s has no other hosts to scan", subnet)
+	}
+	size := 1 << uint(bits-ones)
+	first, last := 1, size-1
+	if ones == 31 {
+		first, last = 0, size
+	}
+	if last-first > arpScanMaxHosts {
+		return nil, fmt.Errorf("subnet %s is too large to scan (%d hosts, max %d)", subnet, last-first, arpScanMaxHosts)
+	}
+
+	network := ipToUint32(subnet.IP)
+	ownAddr := ipToUint32(own)
+	var hosts []net.IP
+	for i := first; i < last; i++ {
+		addr := network + uint32(i)
+		if addr == ownAddr {
+			continue
+		}
+		ip := make(net.IP, net.IPv4len)
+		binary.BigEndian.PutUint32(ip, addr)
+		hosts = append(hosts, ip)
+	}
+	return hosts, nil
+}
+
+// buildARPRequest serializes a broadcast ARP "who-has" request.
+func buildARPRequest(srcMAC net.HardwareAddr, srcIP net.IP, target net.IP) ([]byte, error) {
+	eth := layers.Ethernet{
+		SrcMAC:       srcMAC,
+		DstMAC:       layers.EthernetBroadcast,
+		EthernetType: layers.EthernetTypeARP,
+	}
+	arp := layers.ARP{
+		AddrType:          layers.LinkTypeEthernet,
+		Protocol:          layers.EthernetTypeIPv4,
+		HwAddressSize:     6,
+		ProtAddressSize:   4,
+		Operation:         layers.ARPRequest,
+		SourceHwAddress:   []byte(srcMAC),
+		SourceProtAddress: []byte(srcIP.To4()),
+		DstHwAddress:      []byte{0, 0, 0, 0, 0, 0},
+		DstProtAddress:    []byte(target.To4()),
+	}
+
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	if err := gopacket.SerializeLayers(buf, opts, &eth, &arp); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func ipToUint32(ip net.IP) uint32 {
+	ip = ip.To4()
+	if ip == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint32(ip)
+}
@@ -0,0 +1,89 @@
+package tools
+
+import (
+	"bytes"
+	"net"
+	"testing"
+)
+
+func TestSubnetHosts(t *testing.T) {
+	tests := []struct {
+		name      string
+		cidr      string
+		own       string
+		wantCount int
+		wantFirst string
+		wantLast  string
+		wantErr   bool
+	}{
+		{name: "/24", cidr: "192.168.1.0/24", own: "192.168.1.10", wantCount: 253, wantFirst: "192.168.1.1", wantLast: "192.168.1.254"},
+		{name: "/24 own address first", cidr: "192.168.1.0/24", own: "192.168.1.1", wantCount: 253, wantFirst: "192.168.1.2", wantLast: "192.168.1.254"},
+		{name: "/30", cidr: "10.0.0.0/30", own: "10.0.0.1", wantCount: 1, wantFirst: "10.0.0.2", wantLast: "10.0.0.2"},
+		{name: "/31 peer only", cidr: "10.0.0.0/31", own: "10.0.0.0", wantCount: 1, wantFirst: "10.0.0.1", wantLast: "10.0.0.1"},
+		{name: "/31 upper address", cidr: "10.0.0.0/31", own: "10.0.0.1", wantCount: 1, wantFirst: "10.0.0.0", wantLast: "10.0.0.0"},
+		{name: "/20 at the host limit", cidr: "172.16.0.0/20", own: "172.16.0.1", wantCount: 4093, wantFirst: "172.16.0.2", wantLast: "172.16.15.254"},
+		{name: "/32", cidr: "10.0.0.1/32", own: "10.0.0.1", wantErr: true},
+		{name: "/19 above the host limit", cidr: "172.16.0.0/19", own: "172.16.0.1", wantErr: true},
+		{name: "IPv6", cidr: "fd00::/120", own: "fd00::1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, subnet, err := net.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			hosts, err := subnetHosts(subnet, net.ParseIP(tt.own))
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("subnetHosts(%s) = %d hosts, want error", tt.cidr, len(hosts))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("subnetHosts(%s) failed: %v", tt.cidr, err)
+			}
+			if len(hosts) != tt.wantCount {
+				t.Fatalf("subnetHosts(%s) = %d hosts, want %d", tt.cidr, len(hosts), tt.wantCount)
+			}
+			if got := hosts[0].String(); got != tt.wantFirst {
+				t.Errorf("first host = %s, want %s", got, tt.wantFirst)
+			}
+			if got := hosts[len(hosts)-1].String(); got != tt.wantLast {
+				t.Errorf("last host = %s, want %s", got, tt.wantLast)
+			}
+			for _, host := range hosts {
+				if host.Equal(net.ParseIP(tt.own)) {
+					t.Errorf("own address %s is scanned", tt.own)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildARPRequest(t *testing.T) {
+	srcMAC := net.HardwareAddr{0x02, 0x11, 0x22, 0x33, 0x44, 0x55}
+	frame, err := buildARPRequest(srcMAC, net.ParseIP("192.168.1.10"), net.ParseIP("192.168.1.1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []byte{
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, // Destination: broadcast
+		0x02, 0x11, 0x22, 0x33, 0x44, 0x55, // Source MAC
+		0x08, 0x06, // EtherType ARP
+		0x00, 0x01, // Hardware type Ethernet
+		0x08, 0x00, // Protocol type IPv4
+		0x06, 0x04, // Address sizes
+		0x00, 0x01, // Operation request
+		0x02, 0x11, 0x22, 0x33, 0x44, 0x55, // Sender MAC
+		192, 168, 1, 10, // Sender IP
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // Target MAC
+		192, 168, 1, 1, // Target IP
+	}
+	// gopacket pads the frame with zeros to the Ethernet minimum of 60 bytes
+	want = append(want, make([]byte, 60-len(want))...)
+	if !bytes.Equal(frame, want) {
+		t.Errorf("buildARPRequest() =\n% x\nwant\n% x", frame, want)
+	}
+}
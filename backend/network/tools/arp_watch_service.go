@@ -0,0 +1,427 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	anynetwork "privacy-buddy/backend/network"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcap"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	arpWatchDefaultInterval = 10 * time.Second
+	arpBindingWindow        = 10 * time.Minute // How long an observed IP/MAC binding is remembered
+	arpAlertSuppression     = 5 * time.Minute  // Identical alerts are not repeated within this period
+	arpMaxIPsPerMAC         = 3
+	arpGratuitousWindow     = 10 * time.Second
+	arpGratuitousThreshold  = 10
+	arpMaxAlertHistory      = 500
+)
+
+// Alert types of the ARP watcher.
+const (
+	ARPAlertIPMultipleMACs     = "ipMultipleMACs"
+	ARPAlertMACMultipleIPs     = "macMultipleIPs"
+	ARPAlertGatewayMACChanged  = "gatewayMACChanged"
+	ARPAlertGratuitousARPFlood = "gratuitousARPFlood"
+)
+
+// ARPAlert describes a suspicious ARP observation that may indicate ARP spoofing.
+type ARPAlert struct {
+	Type         string   `json:"type"`
+	Severity     string   `json:"severity"` // "warning" or "critical"
+	IPAddresses  []string `json:"ipAddresses"`
+	MACAddresses []string `json:"macAddresses"`
+	Message      string   `json:"message"`
+	Source       string   `json:"source"` // "arp-cache" or "sniffer"
+	Timestamp    string   `json:"timestamp"`
+}
+
+// ARPWatchService polls the ARP cache and optionally sniffs ARP replies to detect
+// man-in-the-middle attacks based on ARP spoofing.
+type ARPWatchService struct {
+	appCtx      context.Context
+	arpCacheSvc anynetwork.ARPCacheService
+
+	mu          sync.Mutex
+	stopWatch   context.CancelFunc
+	ipToMACs    map[string]map[string]time.Time
+	macToIPs    map[string]map[string]time.Time
+	gatewayIP   string
+	gatewayMAC  string
+	gratuitous  map[string][]time.Time
+	alerts      []ARPAlert
+	lastAlerted map[string]time.Time
+}
+
+// NewARPWatchService creates a new ARPWatchService that polls the given ARP cache service.
+func NewARPWatchService(arpCacheSvc anynetwork.ARPCacheService) *ARPWatchService {
+	return &ARPWatchService{
+		arpCacheSvc: arpCacheSvc,
+		lastAlerted: make(map[string]time.Time),
+	}
+}
+
+// WailsInit stores the application context used to emit alerts.
+func (s *ARPWatchService) WailsInit(ctx context.Context) {
+	s.appCtx = ctx
+}
+
+// StartARPWatch starts polling the ARP cache every intervalSeconds. If sniffInterface is
+// not empty, ARP packets on that interface are inspected as well.
+func (s *ARPWatchService) StartARPWatch(intervalSeconds int, sniffInterface string) error {
+	if s.arpCacheSvc == nil {
+		return fmt.Errorf("ARP cache service not available")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopWatch != nil {
+		return fmt.Errorf("ARP watch is already running")
+	}
+
+	interval := arpWatchDefaultInterval
+	if intervalSeconds > 0 {
+		interval = time.Duration(intervalSeconds) * time.Second
+	}
+
+	var handle *pcap.Handle
+	if sniffInterface != "" {
+		var err error
+		handle, err = pcap.OpenLive(sniffInterface, 128, false, time.Second)
+		if err != nil {
+			return fmt.Errorf("error opening device %s: %w", sniffInterface, err)
+		}
+		if err := handle.SetBPFFilter("arp"); err != nil {
+			handle.Close()
+			return fmt.Errorf("error setting BPF filter: %w", err)
+		}
+	}
+
+	s.ipToMACs = make(map[string]map[string]time.Time)
+	s.macToIPs = make(map[string]map[string]time.Time)
+	s.gratuitous = make(map[string][]time.Time)
+	s.gatewayIP, s.gatewayMAC = "", ""
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stopWatch = cancel
+
+	go s.pollLoop(ctx, interval)
+	if handle != nil {
+		go s.sniffLoop(ctx, handle)
+	}
+	log.Printf("ARP watch started (interval %s, sniffing on %q)", interval, sniffInterface)
+	return nil
+}
+
+// StopARPWatch stops polling and sniffing.
+func (s *ARPWatchService) StopARPWatch() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopWatch != nil {
+		s.stopWatch()
+		s.stopWatch = nil
+	}
+}
+
+// IsARPWatchRunning reports whether the watcher is active.
+func (s *ARPWatchService) IsARPWatchRunning() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stopWatch != nil
+}
+
+// GetARPAlerts returns the alert history, newest first.
+func (s *ARPWatchService) GetARPAlerts() []ARPAlert {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	alerts := make([]ARPAlert, len(s.alerts))
+	for i, alert := range s.alerts {
+		alerts[len(s.alerts)-1-i] = alert
+	}
+	return alerts
+}
+
+// ClearARPAlerts clears the alert history.
+func (s *ARPWatchService) ClearARPAlerts() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.alerts = nil
+	s.lastAlerted = make(map[string]time.Time)
+}
+
+func (s *ARPWatchService) pollLoop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.pollARPCache()
+		select {
+		case <-ctx.Done():
+			log.Println("ARP watch stopped.")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// pollARPCache feeds the current ARP cache into the detector and checks the gateway binding.
+func (s *ARPWatchService) pollARPCache() {
+	entries, err := s.arpCacheSvc.GetARPEntries()
+	if err != nil {
+		log.Printf("WARN: ARP watch could not read ARP cache: %v", err)
+		return
+	}
+
+	gatewayIP, err := anynetwork.DefaultGatewayIP()
+	if err != nil {
+		gatewayIP = ""
+	}
+
+	gatewayMAC := ""
+	for _, entry := range entries {
+		if entry.Family == "IPv6" || entry.State == "INCOMPLETE" || entry.State == "FAILED" {
+			continue
+		}
+		mac, ok := normalizeMAC(entry.MACAddress)
+		if !ok {
+			continue
+		}
+		s.observe(entry.IPAddress, mac, "arp-cache")
+		if entry.IPAddress == gatewayIP {
+			gatewayMAC = mac
+		}
+	}
+
+	if gatewayIP != "" && gatewayMAC != "" {
+		s.checkGateway(gatewayIP, gatewayMAC)
+	}
+	s.pruneGratuitous(time.Now())
+}
+
+func (s *ARPWatchService) sniffLoop(ctx context.Context, handle *pcap.Handle) {
+	defer handle.Close()
+	packetSource := gopacket.NewPacketSource(handle, handle.LinkType())
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case packet, ok := <-packetSource.Packets():
+			if !ok {
+				log.Println("ARP sniffer packet source closed.")
+				return
+			}
+			arpLayer := packet.Layer(layers.LayerTypeARP)
+			if arpLayer == nil {
+				continue
+			}
+			arp := arpLayer.(*layers.ARP)
+			senderIP := net.IP(arp.SourceProtAddress)
+			targetIP := net.IP(arp.DstProtAddress)
+			gratuitous := senderIP.Equal(targetIP)
+
+			if arp.Operation != layers.ARPReply && !gratuitous {
+				continue
+			}
+			senderMAC, ok := normalizeMAC(net.HardwareAddr(arp.SourceHwAddress).String())
+			if !ok || senderIP.IsUnspecified() {
+				continue
+			}
+			s.observe(senderIP.String(), senderMAC, "sniffer")
+			if gratuitous {
+				s.observeGratuitous(senderIP.String(), senderMAC)
+			}
+		}
+	}
+}
+
+// observe records an IP/MAC binding and raises alerts for conflicting bindings.
+func (s *ARPWatchService) observe(ip string, mac string, source string) {
+	anynetwork.GetDeviceInventoryService().ObserveDevice(mac, ip, source)
+	now := time.Now()
+	var alerts []ARPAlert
+
+	s.mu.Lock()
+	addBinding(s.ipToMACs, ip, mac, now)
+	addBinding(s.macToIPs, mac, ip, now)
+	macs := activeBindings(s.ipToMACs[ip], now)
+	ips := activeBindings(s.macToIPs[mac], now)
+	gatewayIP := s.gatewayIP
+	s.mu.Unlock()
+
+	if len(macs) > 1 {
+		severity := "warning"
+		if ip == gatewayIP {
+			severity = "critical"
+		}
+		alerts = append(alerts, ARPAlert{
+			Type:         ARPAlertIPMultipleMACs,
+			Severity:     severity,
+			IPAddresses:  []string{ip},
+			MACAddresses: macs,
+			Message:      fmt.Sprintf("IP %s is claimed by %d MAC addresses: %s", ip, len(macs), strings.Join(macs, ", ")),
+			Source:       source,
+		})
+	}
+
+	claimsGateway := gatewayIP != "" && len(ips) > 1 && containsString(ips, gatewayIP)
+	if claimsGateway || len(ips) >= arpMaxIPsPerMAC {
+		severity := "warning"
+		if claimsGateway {
+			severity = "critical"
+		}
+		alerts = append(alerts, ARPAlert{
+			Type:         ARPAlertMACMultipleIPs,
+			Severity:     severity,
+			IPAddresses:  ips,
+			MACAddresses: []string{mac},
+			Message:      fmt.Sprintf("MAC %s claims %d IP addresses: %s", mac, len(ips), strings.Join(ips, ", ")),
+			Source:       source,
+		})
+	}
+
+	for _, alert := range alerts {
+		s.raiseAlert(alert)
+	}
+}
+
+// observeGratuitous counts gratuitous ARP packets per sender and raises an alert on floods.
+func (s *ARPWatchService) observeGratuitous(ip string, mac string) {
+	now := time.Now()
+
+	s.mu.Lock()
+	recent := s.gratuitous[mac][:0]
+	for _, t := range s.gratuitous[mac] {
+		if now.Sub(t) <= arpGratuitousWindow {
+			recent = append(recent, t)
+		}
+	}
+	recent = append(recent, now)
+	s.gratuitous[mac] = recent
+	count := len(recent)
+	s.mu.Unlock()
+
+	if count > arpGratuitousThreshold {
+		s.raiseAlert(ARPAlert{
+			Type:         ARPAlertGratuitousARPFlood,
+			Severity:     "warning",
+			IPAddresses:  []string{ip},
+			MACAddresses: []string{mac},
+			Message:      fmt.Sprintf("MAC %s sent %d gratuitous ARP packets for %s within %s", mac, count, ip, arpGratuitousWindow),
+			Source:       "sniffer",
+		})
+	}
+}
+
+// pruneGratuitous drops the senders that sent no gratuitous ARP packet within the window.
+func (s *ARPWatchService) pruneGratuitous(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for mac, times := range s.gratuitous {
+		if len(times) == 0 || now.Sub(times[len(times)-1]) > arpGratuitousWindow {
+			delete(s.gratuitous, mac)
+		}
+	}
+}
+
+// checkGateway raises a critical alert if the MAC address of the default gateway changes.
+func (s *ARPWatchService) checkGateway(gatewayIP string, gatewayMAC string) {
+	s.mu.Lock()
+	previousIP, previousMAC := s.gatewayIP, s.gatewayMAC
+	s.gatewayIP, s.gatewayMAC = gatewayIP, gatewayMAC
+	s.mu.Unlock()
+
+	if previousIP == gatewayIP && previousMAC != "" && previousMAC != gatewayMAC {
+		s.raiseAlert(ARPAlert{
+			Type:         ARPAlertGatewayMACChanged,
+			Severity:     "critical",
+			IPAddresses:  []string{gatewayIP},
+			MACAddresses: []string{previousMAC, gatewayMAC},
+			Message:      fmt.Sprintf("MAC address of default gateway %s changed from %s to %s", gatewayIP, previousMAC, gatewayMAC),
+			Source:       "arp-cache",
+		})
+	}
+}
+
+// raiseAlert stores the alert and emits it, unless the same alert was raised recently.
+func (s *ARPWatchService) raiseAlert(alert ARPAlert) {
+	now := time.Now()
+	key := alert.Type + "|" + strings.Join(alert.IPAddresses, ",") + "|" + strings.Join(alert.MACAddresses, ",")
+
+	s.mu.Lock()
+	if last, ok := s.lastAlerted[key]; ok && now.Sub(last) < arpAlertSuppression {
+		s.mu.Unlock()
+		return
+	}
+	s.lastAlerted[key] = now
+	alert.Timestamp = now.Format(time.RFC3339)
+	s.alerts = append(s.alerts, alert)
+	if len(s.alerts) > arpMaxAlertHistory {
+		s.alerts = s.alerts[len(s.alerts)-arpMaxAlertHistory:]
+	}
+	s.mu.Unlock()
+
+	log.Printf("ARP alert (%s): %s", alert.Severity, alert.Message)
+	if s.appCtx != nil {
+		runtime.EventsEmit(s.appCtx, "arpAlert", alert)
+	}
+}
+
+func addBinding(bindings map[string]map[string]time.Time, key string, value string, now time.Time) {
+	if bindings[key] == nil {
+		bindings[key] = make(map[string]time.Time)
+	}
+	bindings[key][value] = now
+}
+
+// activeBindings returns the values seen within the binding window and forgets older ones.
+func activeBindings(values map[string]time.Time, now time.Time) []string {
+	var active []string
+	for value, seen := range values {
+		if now.Sub(seen) > arpBindingWindow {
+			delete(values, value)
+			continue
+		}
+		active = append(active, value)
+	}
+	sort.Strings(active)
+	return active
+}
+
+// normalizeMAC converts a MAC address into the lowercase, colon-separated form and
+// rejects empty, all-zero and broadcast addresses.
+func normalizeMAC(mac string) (string, bool) {
+	hw, err := net.ParseMAC(mac)
+	if err != nil {
+		return "", false
+	}
+	zero, broadcast := true, true
+	for _, b := range hw {
+		zero = zero && b == 0x00
+		broadcast = broadcast && b == 0xff
+	}
+	if zero || broadcast {
+		return "", false
+	}
+	return hw.String(), true
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
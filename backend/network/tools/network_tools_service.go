@@ -3,7 +3,6 @@ package tools
 import (
 	"bytes"
 	"fmt"
-	"log"
 	"net"
 	"os/exec"
 	"regexp"
@@ -15,12 +14,17 @@ import (
 	anynetwork "privacy-buddy/backend/network"
 
 	"github.com/go-ping/ping"
-	"github.com/google/gopacket/pcap" // Hinzugefügt für Netzwerkschnittstellen-Erkennung
 )
 
 // NetworkToolsService bietet Funktionen für Netzwerkdiagnose-Tools.
 type NetworkToolsService struct {
 	tracerouteSvc TracerouteService
+	inventory     InterfaceInventory
+}
+
+// InterfaceInventory liefert das vereinheitlichte Schnittstelleninventar (net, sysfs und pcap).
+type InterfaceInventory interface {
+	GetNetworkInterfaces() ([]anynetwork.NetworkInterface, error)
 }
 
 // TracerouteService definiert die Schnittstelle für plattformspezifische Traceroute-Implementierungen.
@@ -30,16 +34,18 @@ type TracerouteService interface {
 
 // TracerouteHop strukturiert einen Hop in einem Traceroute-Ergebnis.
 type TracerouteHop struct {
-	N       int    `json:"n"`
-	Host    string `json:"host"`
-	Address string `json:"address"`
-	RTT     string `json:"rtt"`
+	N        int    `json:"n"`
+	Host     string `json:"host"`
+	Address  string `json:"address"`
+	RTT      string `json:"rtt"`
+	Hostname string `json:"hostname"` // Aus dem Reverse-DNS-Cache, falls die Ausgabe keinen Namen enthält
 }
 
 // NewNetworkToolsService erstellt eine neue Instanz des NetworkToolsService.
-func NewNetworkToolsService(tracerouteSvc TracerouteService) *NetworkToolsService {
+func NewNetworkToolsService(tracerouteSvc TracerouteService, inventory InterfaceInventory) *NetworkToolsService {
 	return &NetworkToolsService{
 		tracerouteSvc: tracerouteSvc,
+		inventory:     inventory,
 	}
 }
 
@@ -217,76 +223,25 @@ func (s *NetworkToolsService) Ping(host string) (*PingResult, error) {
 }
 
 // Traceroute führt einen Traceroute-Befehl aus und gibt die Ergebnisse zurück.
+// Hop-Namen, die noch nicht im Reverse-DNS-Cache sind, werden per "reverseDNSResolved"-Event nachgereicht.
 func (s *NetworkToolsService) Traceroute(host string) ([]TracerouteHop, error) {
-	return s.tracerouteSvc.Traceroute(host)
-}
-
-// GetNetworkInterfaces listet alle verfügbaren Netzwerkschnittstellen auf.
-func (s *NetworkToolsService) GetNetworkInterfaces() ([]anynetwork.NetworkInterface, error) {
-	var interfaces []anynetwork.NetworkInterface
-
-	// Get interfaces from pcap for the actual device name needed by pcap.OpenLive
-	pcapDevs, err := pcap.FindAllDevs()
+	hops, err := s.tracerouteSvc.Traceroute(host)
 	if err != nil {
-		return nil, fmt.Errorf("error finding pcap devices: %w", err)
+		return nil, err
 	}
-
-	// Get interfaces from net package for IP addresses, MAC, and status
-	netDevs, err := net.Interfaces()
-	if err != nil {
-		return nil, fmt.Errorf("error finding net interfaces: %w", err)
-	}
-
-	// Create a map for net.Interfaces for quick lookup by name
-	netDevMap := make(map[string]net.Interface)
-	for _, dev := range netDevs {
-		netDevMap[dev.Name] = dev
-	}
-
-	for _, pcapDev := range pcapDevs {
-		log.Printf("PCAP Device - Name: %s, Description: %s", pcapDev.Name, pcapDev.Description)
-		var addresses []string
-		var hardwareAddr net.HardwareAddr
-		var mtu int
-		var flagsList []string
-		var isUp, isLoopback, isBroadcast, isPointToPoint, isMulticast bool
-
-		// Try to find the corresponding net.Interface
-		if netDev, found := netDevMap[pcapDev.Name]; found {
-			addrs, _ := netDev.Addrs()
-			for _, addr := range addrs {
-				addresses = append(addresses, addr.String())
-			}
-			hardwareAddr = netDev.HardwareAddr
-			mtu = netDev.MTU
-			flagsList = strings.Split(netDev.Flags.String(), ",")
-			isUp = (netDev.Flags & net.FlagUp) != 0
-			isLoopback = (netDev.Flags & net.FlagLoopback) != 0
-			isBroadcast = (netDev.Flags & net.FlagBroadcast) != 0
-			isPointToPoint = (netDev.Flags & net.FlagPointToPoint) != 0
-			isMulticast = (netDev.Flags & net.FlagMulticast) != 0
-		} else {
-			// Fallback for addresses if net.Interface not found (less common for pcap devices)
-			for _, addr := range pcapDev.Addresses {
-				addresses = append(addresses, addr.IP.String())
-			}
+	rdns := anynetwork.GetReverseDNSService()
+	for i := range hops {
+		if hops[i].Host != hops[i].Address {
+			hops[i].Hostname = hops[i].Host
+			continue
 		}
-
-		interfaces = append(interfaces, anynetwork.NetworkInterface{
-			Name:           pcapDev.Name,        // This is the name pcap.OpenLive expects
-			DisplayName:    pcapDev.Description, // User-friendly name
-			Description:    pcapDev.Description,
-			HardwareAddr:   hardwareAddr,
-			MTU:            mtu,
-			Flags:          flagsList,
-			Addrs:          addresses,
-			IsUp:           isUp,
-			IsLoopback:     isLoopback,
-			IsBroadcast:    isBroadcast,
-			IsPointToPoint: isPointToPoint,
-			IsMulticast:    isMulticast,
-		})
+		hops[i].Hostname = rdns.Hostname(hops[i].Address)
 	}
+	return hops, nil
+}
 
-	return interfaces, nil
-}
\ No newline at end of file
+// GetNetworkInterfaces listet alle Netzwerkschnittstellen aus dem vereinheitlichten Inventar auf,
+// einschließlich der Pseudo-Geräte von libpcap und der Angabe, ob darauf mitgeschnitten werden kann.
+func (s *NetworkToolsService) GetNetworkInterfaces() ([]anynetwork.NetworkInterface, error) {
+	return s.inventory.GetNetworkInterfaces()
+}
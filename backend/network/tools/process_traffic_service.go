@@ -0,0 +1,341 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	anynetwork "privacy-buddy/backend/network"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/pcap"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	processTrafficDefaultInterval = 2 * time.Second
+	processTrafficHistorySize     = 60              // Samples kept per process
+	processTrafficEndpointIdle    = 5 * time.Minute // Endpoints without traffic are dropped after this
+)
+
+// TrafficSample is the data rate of a process in one sampling interval.
+type TrafficSample struct {
+	Timestamp  string  `json:"timestamp"`
+	RateInBps  float64 `json:"rateInBps"`
+	RateOutBps float64 `json:"rateOutBps"`
+}
+
+// ProcessTraffic holds the totals and current rates of one process.
+type ProcessTraffic struct {
+	PID         int32           `json:"pid"`
+	ProcessName string          `json:"processName"`
+	BytesIn     uint64          `json:"bytesIn"`
+	BytesOut    uint64          `json:"bytesOut"`
+	PacketsIn   uint64          `json:"packetsIn"`
+	PacketsOut  uint64          `json:"packetsOut"`
+	RateInBps   float64         `json:"rateInBps"`
+	RateOutBps  float64         `json:"rateOutBps"`
+	History     []TrafficSample `json:"history"`
+}
+
+// EndpointTraffic holds the totals and current rates of one remote endpoint of a process.
+type EndpointTraffic struct {
+	Protocol       string  `json:"protocol"`
+	RemoteIP       string  `json:"remoteIP"`
+	RemotePort     uint16  `json:"remotePort"`
+	RemoteHostname string  `json:"remoteHostname"`
+	PID            int32   `json:"pid"`
+	ProcessName    string  `json:"processName"`
+	BytesIn        uint64  `json:"bytesIn"`
+	BytesOut       uint64  `json:"bytesOut"`
+	RateInBps      float64 `json:"rateInBps"`
+	RateOutBps     float64 `json:"rateOutBps"`
+}
+
+// ProcessTrafficSnapshot is returned by GetProcessTraffic and emitted as "processTraffic" event.
+type ProcessTrafficSnapshot struct {
+	Timestamp string            `json:"timestamp"`
+	Interface string            `json:"interface"`
+	Running   bool              `json:"running"`
+	Processes []ProcessTraffic  `json:"processes"`
+	Endpoints []EndpointTraffic `json:"endpoints"`
+}
+
+type trafficCounter struct {
+	bytesIn, bytesOut     uint64
+	packetsIn, packetsOut uint64
+	lastIn, lastOut       uint64 // Totals at the previous sample
+	rateIn, rateOut       float64
+}
+
+// sample updates the rates from the bytes counted since the previous sample.
+func (c *trafficCounter) sample(seconds float64) {
+	c.rateIn = float64(c.bytesIn-c.lastIn) / seconds
+	c.rateOut = float64(c.bytesOut-c.lastOut) / seconds
+	c.lastIn, c.lastOut = c.bytesIn, c.bytesOut
+}
+
+type processTrafficRecord struct {
+	trafficCounter
+	pid     int32
+	name    string
+	history []TrafficSample
+}
+
+type endpointTrafficRecord struct {
+	trafficCounter
+	protocol   string
+	remoteIP   string
+	remotePort uint16
+	pid        int32
+	name       string
+	lastActive time.Time
+}
+
+// ProcessTrafficService attributes captured bytes to processes by matching the local
+// endpoint of each packet against the socket table of the NetworkConnectionService.
+type ProcessTrafficService struct {
+	appCtx  context.Context
+	sockets *socketTable
+
+	mu         sync.Mutex
+	stop       context.CancelFunc
+	iface      string
+	processes  map[string]*processTrafficRecord
+	endpoints  map[string]*endpointTrafficRecord
+	lastSample time.Time
+}
+
+// NewProcessTrafficService creates a new ProcessTrafficService.
+func NewProcessTrafficService(connectionService anynetwork.NetworkConnectionService) *ProcessTrafficService {
+	return &ProcessTrafficService{
+		sockets:   newSocketTable(connectionService),
+		processes: make(map[string]*processTrafficRecord),
+		endpoints: make(map[string]*endpointTrafficRecord),
+	}
+}
+
+// WailsInit stores the application context used to emit traffic events.
+func (s *ProcessTrafficService) WailsInit(ctx context.Context) {
+	s.appCtx = ctx
+}
+
+// StartProcessTraffic starts capturing on the interface and emits a "processTraffic"
+// event every intervalSeconds (0 uses the default). Totals are reset.
+func (s *ProcessTrafficService) StartProcessTraffic(iface string, intervalSeconds int) error {
+	interval := processTrafficDefaultInterval
+	if intervalSeconds > 0 {
+		interval = time.Duration(intervalSeconds) * time.Second
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil {
+		return fmt.Errorf("process traffic accounting is already running")
+	}
+
+	// Only the headers are needed; the original packet length is taken from the metadata.
+	handle, err := pcap.OpenLive(iface, 128, false, time.Second)
+	if err != nil {
+		return fmt.Errorf("error opening device %s: %w", iface, err)
+	}
+	if err := handle.SetBPFFilter("tcp or udp"); err != nil {
+		handle.Close()
+		return fmt.Errorf("error setting BPF filter: %w", err)
+	}
+
+	s.sockets.refresh()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stop = cancel
+	s.iface = iface
+	s.processes = make(map[string]*processTrafficRecord)
+	s.endpoints = make(map[string]*endpointTrafficRecord)
+	s.lastSample = time.Now()
+
+	go s.captureLoop(ctx, handle)
+	go s.sampleLoop(ctx, interval)
+	log.Printf("Process traffic accounting started on %s (interval %s).", iface, interval)
+	return nil
+}
+
+// StopProcessTraffic stops the capture. The totals remain available.
+func (s *ProcessTrafficService) StopProcessTraffic() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil {
+		s.stop()
+		s.stop = nil
+		log.Println("Process traffic accounting stopped.")
+	}
+}
+
+// GetProcessTraffic returns totals and rates per process and per remote endpoint,
+// ordered by total bytes.
+func (s *ProcessTrafficService) GetProcessTraffic() ProcessTrafficSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.snapshotLocked(time.Now())
+}
+
+func (s *ProcessTrafficService) captureLoop(ctx context.Context, handle *pcap.Handle) {
+	defer handle.Close()
+	packetSource := gopacket.NewPacketSource(handle, handle.LinkType())
+	packetSource.DecodeOptions = gopacket.DecodeOptions{Lazy: true, NoCopy: true}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case packet, ok := <-packetSource.Packets():
+			if !ok {
+				log.Println("Process traffic packet source closed.")
+				return
+			}
+			s.account(packet)
+		}
+	}
+}
+
+func (s *ProcessTrafficService) sampleLoop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			snapshot := s.sample(now)
+			if s.appCtx != nil {
+				runtime.EventsEmit(s.appCtx, "processTraffic", snapshot)
+			}
+		}
+	}
+}
+
+// account attributes one packet to the process owning its local endpoint. Packets
+// between two local sockets are counted as sent by one process and received by the other.
+func (s *ProcessTrafficService) account(packet gopacket.Packet) {
+	flow, ok := s.sockets.flow(packet)
+	if !ok {
+		return
+	}
+	length := uint64(packet.Metadata().Length)
+	s.accountFlow(flow, length)
+	if peer, ok := s.sockets.loopbackPeer(flow); ok {
+		s.accountFlow(peer, length)
+	}
+}
+
+func (s *ProcessTrafficService) accountFlow(flow packetFlow, length uint64) {
+	owner := s.sockets.owner(flow)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	processKey := fmt.Sprintf("%d/%s", owner.pid, owner.name)
+	proc, ok := s.processes[processKey]
+	if !ok {
+		proc = &processTrafficRecord{pid: owner.pid, name: owner.name}
+		s.processes[processKey] = proc
+	}
+	endpointKey := fmt.Sprintf("%s/%s/%d/%s", flow.protocol, flow.remoteIP, flow.remotePort, processKey)
+	endpoint, ok := s.endpoints[endpointKey]
+	if !ok {
+		endpoint = &endpointTrafficRecord{protocol: flow.protocol, remoteIP: flow.remoteIP.String(), remotePort: flow.remotePort, pid: owner.pid, name: owner.name}
+		s.endpoints[endpointKey] = endpoint
+	}
+	endpoint.lastActive = time.Now()
+
+	if flow.outbound {
+		proc.bytesOut += length
+		proc.packetsOut++
+		endpoint.bytesOut += length
+		endpoint.packetsOut++
+	} else {
+		proc.bytesIn += length
+		proc.packetsIn++
+		endpoint.bytesIn += length
+		endpoint.packetsIn++
+	}
+}
+
+// sample computes the rates since the previous sample, drops endpoints idle for longer
+// than processTrafficEndpointIdle and refreshes the socket table.
+func (s *ProcessTrafficService) sample(now time.Time) ProcessTrafficSnapshot {
+	s.sockets.refreshIfStale()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seconds := now.Sub(s.lastSample).Seconds()
+	if seconds <= 0 {
+		seconds = 1
+	}
+	s.lastSample = now
+	timestamp := now.Format(time.RFC3339)
+	for _, proc := range s.processes {
+		proc.sample(seconds)
+		proc.history = append(proc.history, TrafficSample{Timestamp: timestamp, RateInBps: proc.rateIn, RateOutBps: proc.rateOut})
+		if len(proc.history) > processTrafficHistorySize {
+			proc.history = proc.history[len(proc.history)-processTrafficHistorySize:]
+		}
+	}
+	for key, endpoint := range s.endpoints {
+		if now.Sub(endpoint.lastActive) > processTrafficEndpointIdle {
+			delete(s.endpoints, key)
+			continue
+		}
+		endpoint.sample(seconds)
+	}
+	return s.snapshotLocked(now)
+}
+
+func (s *ProcessTrafficService) snapshotLocked(now time.Time) ProcessTrafficSnapshot {
+	snapshot := ProcessTrafficSnapshot{
+		Timestamp: now.Format(time.RFC3339),
+		Interface: s.iface,
+		Running:   s.stop != nil,
+		Processes: make([]ProcessTraffic, 0, len(s.processes)),
+		Endpoints: make([]EndpointTraffic, 0, len(s.endpoints)),
+	}
+	for _, proc := range s.processes {
+		snapshot.Processes = append(snapshot.Processes, ProcessTraffic{
+			PID:         proc.pid,
+			ProcessName: proc.name,
+			BytesIn:     proc.bytesIn,
+			BytesOut:    proc.bytesOut,
+			PacketsIn:   proc.packetsIn,
+			PacketsOut:  proc.packetsOut,
+			RateInBps:   proc.rateIn,
+			RateOutBps:  proc.rateOut,
+			History:     append([]TrafficSample(nil), proc.history...),
+		})
+	}
+	rdns := anynetwork.GetReverseDNSService()
+	for _, endpoint := range s.endpoints {
+		snapshot.Endpoints = append(snapshot.Endpoints, EndpointTraffic{
+			Protocol:       endpoint.protocol,
+			RemoteIP:       endpoint.remoteIP,
+			RemotePort:     endpoint.remotePort,
+			RemoteHostname: rdns.Hostname(endpoint.remoteIP),
+			PID:            endpoint.pid,
+			ProcessName:    endpoint.name,
+			BytesIn:        endpoint.bytesIn,
+			BytesOut:       endpoint.bytesOut,
+			RateInBps:      endpoint.rateIn,
+			RateOutBps:     endpoint.rateOut,
+		})
+	}
+	sort.Slice(snapshot.Processes, func(i, j int) bool {
+		return snapshot.Processes[i].BytesIn+snapshot.Processes[i].BytesOut > snapshot.Processes[j].BytesIn+snapshot.Processes[j].BytesOut
+	})
+	sort.Slice(snapshot.Endpoints, func(i, j int) bool {
+		return snapshot.Endpoints[i].BytesIn+snapshot.Endpoints[i].BytesOut > snapshot.Endpoints[j].BytesIn+snapshot.Endpoints[j].BytesOut
+	})
+	return snapshot
+}
@@ -0,0 +1,181 @@
+package tools
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	anynetwork "privacy-buddy/backend/network"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+const (
+	socketTableRefreshInterval = 2 * time.Second // Regular refresh of the socket table
+	socketTableMissRefresh     = 500 * time.Millisecond
+	unknownProcessName         = "unknown"
+)
+
+// socketOwnerInfo is the process owning a local socket.
+type socketOwnerInfo struct {
+	pid  int32
+	name string
+}
+
+// packetFlow is the local and remote endpoint of a captured TCP or UDP packet.
+type packetFlow struct {
+	protocol   string
+	localIP    net.IP
+	localPort  uint16
+	remoteIP   net.IP
+	remotePort uint16
+	outbound   bool
+}
+
+// socketTable maps local endpoints to the processes owning them, using the socket table
+// of the NetworkConnectionService, and knows the IP addresses of this host.
+type socketTable struct {
+	connectionService anynetwork.NetworkConnectionService
+
+	mu         sync.Mutex
+	sockets    map[string]socketOwnerInfo
+	localIPs   map[string]struct{}
+	updated    time.Time
+	refreshing bool // A refresh started by owner is running
+}
+
+func newSocketTable(connectionService anynetwork.NetworkConnectionService) *socketTable {
+	return &socketTable{connectionService: connectionService}
+}
+
+// flow extracts the endpoints of a TCP or UDP packet. ok is false for other packets and
+// for packets that neither come from nor go to this host.
+func (t *socketTable) flow(packet gopacket.Packet) (packetFlow, bool) {
+	var srcIP, dstIP net.IP
+	if ip4, ok := packet.Layer(layers.LayerTypeIPv4).(*layers.IPv4); ok {
+		srcIP, dstIP = ip4.SrcIP, ip4.DstIP
+	} else if ip6, ok := packet.Layer(layers.LayerTypeIPv6).(*layers.IPv6); ok {
+		srcIP, dstIP = ip6.SrcIP, ip6.DstIP
+	} else {
+		return packetFlow{}, false
+	}
+
+	var protocol string
+	var srcPort, dstPort uint16
+	if tcp, ok := packet.Layer(layers.LayerTypeTCP).(*layers.TCP); ok {
+		protocol, srcPort, dstPort = "tcp", uint16(tcp.SrcPort), uint16(tcp.DstPort)
+	} else if udp, ok := packet.Layer(layers.LayerTypeUDP).(*layers.UDP); ok {
+		protocol, srcPort, dstPort = "udp", uint16(udp.SrcPort), uint16(udp.DstPort)
+	} else {
+		return packetFlow{}, false
+	}
+
+	if t.isLocalIP(srcIP) {
+		return packetFlow{protocol: protocol, localIP: srcIP, localPort: srcPort, remoteIP: dstIP, remotePort: dstPort, outbound: true}, true
+	}
+	if t.isLocalIP(dstIP) {
+		return packetFlow{protocol: protocol, localIP: dstIP, localPort: dstPort, remoteIP: srcIP, remotePort: srcPort}, true
+	}
+	return packetFlow{}, false
+}
+
+// owner finds the process owning the local endpoint of a flow. Sockets bound to the
+// wildcard address match any local IP. On a miss a refresh of the socket table is started
+// in the background, rate limited, so that connections opened since the last refresh are
+// found for later packets without walking /proc on the packet path.
+func (t *socketTable) owner(flow packetFlow) socketOwnerInfo {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if owner, ok := t.findLocked(flow.protocol, flow.localIP, flow.localPort); ok {
+		return owner
+	}
+	if !t.refreshing && time.Since(t.updated) >= socketTableMissRefresh {
+		t.refreshing = true
+		go t.refresh()
+	}
+	return socketOwnerInfo{name: unknownProcessName}
+}
+
+// loopbackPeer returns the receiving side of an outbound flow between two local sockets,
+// e.g. over the loopback interface, where the same packet belongs to both processes.
+func (t *socketTable) loopbackPeer(flow packetFlow) (packetFlow, bool) {
+	if !flow.outbound || !t.isLocalIP(flow.remoteIP) {
+		return packetFlow{}, false
+	}
+	return packetFlow{
+		protocol:   flow.protocol,
+		localIP:    flow.remoteIP,
+		localPort:  flow.remotePort,
+		remoteIP:   flow.localIP,
+		remotePort: flow.localPort,
+	}, true
+}
+
+func (t *socketTable) findLocked(protocol string, ip net.IP, port uint16) (socketOwnerInfo, bool) {
+	if owner, ok := t.sockets[socketKey(protocol, ip.String(), port)]; ok {
+		return owner, true
+	}
+	owner, ok := t.sockets[socketKey(protocol, "*", port)]
+	return owner, ok
+}
+
+// refreshIfStale reloads the table if the last refresh is older than socketTableRefreshInterval.
+func (t *socketTable) refreshIfStale() {
+	t.mu.Lock()
+	stale := time.Since(t.updated) >= socketTableRefreshInterval
+	t.mu.Unlock()
+	if stale {
+		t.refresh()
+	}
+}
+
+// refresh reloads the socket table and the local IP addresses.
+func (t *socketTable) refresh() {
+	sockets := make(map[string]socketOwnerInfo)
+	if t.connectionService != nil {
+		conns, err := t.connectionService.GetConnections()
+		if err != nil {
+			log.Printf("WARN: Could not read socket table: %v", err)
+		}
+		for _, conn := range conns {
+			if conn.PID == 0 {
+				continue
+			}
+			localIP := "*"
+			if ip := net.ParseIP(conn.LocalIP); ip != nil && !ip.IsUnspecified() {
+				localIP = ip.String() // Also unmaps IPv4-mapped IPv6 addresses
+			}
+			sockets[socketKey(anynetwork.ConnectionProtocol(conn), localIP, uint16(conn.LocalPort))] = socketOwnerInfo{pid: conn.PID, name: conn.ProcessName}
+		}
+	}
+
+	localIPs := make(map[string]struct{})
+	if addrs, err := net.InterfaceAddrs(); err == nil {
+		for _, addr := range addrs {
+			if ipnet, ok := addr.(*net.IPNet); ok {
+				localIPs[ipnet.IP.String()] = struct{}{}
+			}
+		}
+	}
+
+	t.mu.Lock()
+	t.sockets = sockets
+	t.localIPs = localIPs
+	t.updated = time.Now()
+	t.refreshing = false
+	t.mu.Unlock()
+}
+
+func (t *socketTable) isLocalIP(ip net.IP) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, ok := t.localIPs[ip.String()]
+	return ok
+}
+
+func socketKey(protocol string, ip string, port uint16) string {
+	return fmt.Sprintf("%s/%s/%d", protocol, ip, port)
+}
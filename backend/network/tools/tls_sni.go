@@ -0,0 +1,105 @@
+package tools
+
+import "encoding/binary"
+
+const (
+	tlsRecordTypeHandshake   = 0x16
+	tlsHandshakeClientHello  = 0x01
+	tlsExtensionServerName   = 0x0000
+	tlsServerNameTypeHost    = 0x00
+	tlsRecordHeaderLength    = 5
+	tlsHandshakeHeaderLength = 4
+)
+
+// extractSNI returns the server name of a TLS ClientHello contained in a TCP payload.
+// It returns an empty string if the payload is not a (complete) ClientHello.
+func extractSNI(payload []byte) string {
+	if len(payload) < tlsRecordHeaderLength+tlsHandshakeHeaderLength || payload[0] != tlsRecordTypeHandshake {
+		return ""
+	}
+	data := payload[tlsRecordHeaderLength:]
+	if data[0] != tlsHandshakeClientHello {
+		return ""
+	}
+	data = data[tlsHandshakeHeaderLength:]
+
+	// Client version (2) + random (32)
+	if len(data) < 34 {
+		return ""
+	}
+	data = data[34:]
+
+	// Session ID
+	data, ok := skipVector(data, 1)
+	if !ok {
+		return ""
+	}
+	// Cipher suites
+	if data, ok = skipVector(data, 2); !ok {
+		return ""
+	}
+	// Compression methods
+	if data, ok = skipVector(data, 1); !ok {
+		return ""
+	}
+
+	if len(data) < 2 {
+		return ""
+	}
+	extLength := int(binary.BigEndian.Uint16(data))
+	data = data[2:]
+	if extLength < len(data) {
+		data = data[:extLength]
+	}
+
+	for len(data) >= 4 {
+		extType := binary.BigEndian.Uint16(data)
+		length := int(binary.BigEndian.Uint16(data[2:]))
+		data = data[4:]
+		if length > len(data) {
+			return ""
+		}
+		if extType == tlsExtensionServerName {
+			return parseServerNameExtension(data[:length])
+		}
+		data = data[length:]
+	}
+	return ""
+}
+
+// parseServerNameExtension returns the first host name of a server_name extension.
+func parseServerNameExtension(data []byte) string {
+	if len(data) < 2 {
+		return ""
+	}
+	data = data[2:] // Server name list length
+	for len(data) >= 3 {
+		nameType := data[0]
+		length := int(binary.BigEndian.Uint16(data[1:]))
+		data = data[3:]
+		if length > len(data) {
+			return ""
+		}
+		if nameType == tlsServerNameTypeHost {
+			return string(data[:length])
+		}
+		data = data[length:]
+	}
+	return ""
+}
+
+// skipVector skips a TLS vector with a length prefix of the given size.
+func skipVector(data []byte, prefixLength int) ([]byte, bool) {
+	if len(data) < prefixLength {
+		return nil, false
+	}
+	length := 0
+	for _, b := range data[:prefixLength] {
+		length = length<<8 | int(b)
+	}
+	data = data[prefixLength:]
+	if length > len(data) {
+		return nil, false
+	}
+	return data[length:], true
+}
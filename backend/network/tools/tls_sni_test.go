@@ -0,0 +1,51 @@
+package tools
+
+import (
+	"crypto/tls"
+	"net"
+	"testing"
+)
+
+// clientHello returns the first TLS record a crypto/tls client sends for serverName.
+func clientHello(t *testing.T, serverName string) []byte {
+	t.Helper()
+	client, server := net.Pipe()
+	defer server.Close()
+	go func() {
+		conn := tls.Client(client, &tls.Config{ServerName: serverName, InsecureSkipVerify: true})
+		conn.Handshake()
+		client.Close()
+	}()
+
+	buf := make([]byte, 4096)
+	n, err := server.Read(buf)
+	if err != nil {
+		t.Fatalf("reading ClientHello: %v", err)
+	}
+	return buf[:n]
+}
+
+func TestExtractSNI(t *testing.T) {
+	hello := clientHello(t, "tracker.example.com")
+
+	tests := []struct {
+		name    string
+		payload []byte
+		want    string
+	}{
+		{"client hello", hello, "tracker.example.com"},
+		{"truncated client hello", hello[:60], ""},
+		{"empty payload", nil, ""},
+		{"http request", []byte("GET / HTTP/1.1\r\nHost: example.com\r\n\r\n"), ""},
+		{"server hello", append([]byte{0x16, 0x03, 0x03, 0x00, 0x40, 0x02}, make([]byte, 64)...), ""},
+		{"client hello without sni", clientHello(t, ""), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractSNI(tt.payload); got != tt.want {
+				t.Errorf("extractSNI() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
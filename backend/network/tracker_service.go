@@ -0,0 +1,511 @@
+package network
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	blocklistDirName = "blocklists"
+	blocklistFileExt = ".list"
+)
+
+var blocklistNamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// trackerBlocklist holds the parsed domains of one imported list.
+type trackerBlocklist struct {
+	name       string
+	exact      map[string]struct{} // hosts-style entries, match only the domain itself
+	subdomains map[string]struct{} // adblock-style ||domain^ rules, match the domain and all subdomains
+	importedAt time.Time
+}
+
+type trackerDomainRecord struct {
+	lists     []string
+	sources   map[string]struct{}
+	processes map[string]struct{}
+	count     int
+	firstSeen time.Time
+	lastSeen  time.Time
+}
+
+type trackerProcessRecord struct {
+	pid     int32
+	name    string
+	domains map[string]struct{}
+	lists   map[string]struct{}
+	count   int
+}
+
+// TrackerService matches DNS queries, TLS SNI and reverse-resolved connection
+// endpoints against tracker blocklists imported into the config directory.
+type TrackerService struct {
+	appCtx            context.Context
+	connectionService NetworkConnectionService
+
+	loadOnce  sync.Once
+	mu        sync.RWMutex
+	lists     map[string]*trackerBlocklist
+	domains   map[string]*trackerDomainRecord
+	processes map[string]*trackerProcessRecord
+}
+
+// NewTrackerService creates a new TrackerService. The connection service is used
+// to match the remote endpoints of active connections.
+func NewTrackerService(connectionService NetworkConnectionService) *TrackerService {
+	return &TrackerService{
+		connectionService: connectionService,
+		lists:             make(map[string]*trackerBlocklist),
+		domains:           make(map[string]*trackerDomainRecord),
+		processes:         make(map[string]*trackerProcessRecord),
+	}
+}
+
+// WailsInit stores the application context used to emit tracker events.
+func (s *TrackerService) WailsInit(ctx context.Context) {
+	s.appCtx = ctx
+}
+
+// ImportBlocklist parses a hosts-format or adblock-style list and stores it under the given name.
+// Importing a list with an existing name replaces it.
+func (s *TrackerService) ImportBlocklist(name string, sourcePath string) (*TrackerList, error) {
+	if !blocklistNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid blocklist name '%s': only letters, digits, '.', '_' and '-' are allowed", name)
+	}
+
+	file, err := os.Open(sourcePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open blocklist %s: %w", sourcePath, err)
+	}
+	defer file.Close()
+
+	list, err := parseBlocklist(name, file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse blocklist %s: %w", sourcePath, err)
+	}
+	if len(list.exact)+len(list.subdomains) == 0 {
+		return nil, fmt.Errorf("no domains found in %s", sourcePath)
+	}
+
+	filePath, err := blocklistFilePath(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeBlocklist(filePath, list); err != nil {
+		return nil, err
+	}
+	list.importedAt = time.Now()
+
+	s.ensureLoaded()
+	s.mu.Lock()
+	s.lists[name] = list
+	s.mu.Unlock()
+
+	log.Printf("Imported tracker blocklist %s with %d domains", name, len(list.exact)+len(list.subdomains))
+	info := list.info()
+	return &info, nil
+}
+
+// ListBlocklists returns all imported blocklists.
+func (s *TrackerService) ListBlocklists() []TrackerList {
+	s.ensureLoaded()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	lists := make([]TrackerList, 0, len(s.lists))
+	for _, list := range s.lists {
+		lists = append(lists, list.info())
+	}
+	sort.Slice(lists, func(i, j int) bool { return lists[i].Name < lists[j].Name })
+	return lists
+}
+
+// RemoveBlocklist deletes an imported blocklist.
+func (s *TrackerService) RemoveBlocklist(name string) error {
+	s.ensureLoaded()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.lists[name]; !ok {
+		return fmt.Errorf("blocklist '%s' not found", name)
+	}
+	filePath, err := blocklistFilePath(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove blocklist file: %w", err)
+	}
+	delete(s.lists, name)
+	return nil
+}
+
+// MatchDomain returns the names of all blocklists that contain the domain.
+func (s *TrackerService) MatchDomain(domain string) []string {
+	domain, ok := normalizeDomain(domain)
+	if !ok {
+		return nil
+	}
+
+	s.ensureLoaded()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var matches []string
+	for name, list := range s.lists {
+		if list.matches(domain) {
+			matches = append(matches, name)
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+// RecordDomain records an observed domain and returns true if it is a known tracker.
+// source describes where the domain was seen, e.g. "dns", "sni" or "rdns".
+func (s *TrackerService) RecordDomain(domain string, source string, pid int32, processName string) bool {
+	domain, ok := normalizeDomain(domain)
+	if !ok {
+		return false
+	}
+	lists := s.MatchDomain(domain)
+	if len(lists) == 0 {
+		return false
+	}
+	if processName == "" {
+		processName = "N/A"
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	record, exists := s.domains[domain]
+	if !exists {
+		record = &trackerDomainRecord{
+			sources:   make(map[string]struct{}),
+			processes: make(map[string]struct{}),
+			firstSeen: now,
+		}
+		s.domains[domain] = record
+	}
+	record.lists = lists
+	record.sources[source] = struct{}{}
+	record.processes[processName] = struct{}{}
+	record.count++
+	record.lastSeen = now
+
+	processKey := fmt.Sprintf("%d/%s", pid, processName)
+	proc, exists := s.processes[processKey]
+	if !exists {
+		proc = &trackerProcessRecord{
+			pid:     pid,
+			name:    processName,
+			domains: make(map[string]struct{}),
+			lists:   make(map[string]struct{}),
+		}
+		s.processes[processKey] = proc
+	}
+	proc.domains[domain] = struct{}{}
+	for _, list := range lists {
+		proc.lists[list] = struct{}{}
+	}
+	proc.count++
+
+	hit := record.view(domain)
+	s.mu.Unlock()
+
+	if s.appCtx != nil {
+		runtime.EventsEmit(s.appCtx, "trackerHit", hit)
+	}
+	return true
+}
+
+// ScanConnections reverse-resolves the remote endpoints of all active connections
+// and records those that resolve to a tracker domain.
+func (s *TrackerService) ScanConnections() (*TrackerHits, error) {
+	if s.connectionService == nil {
+		return nil, fmt.Errorf("network connection service not available")
+	}
+	conns, err := s.connectionService.GetConnections()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network connections: %w", err)
+	}
+
+	hostnames := GetReverseDNSService().LookupAll(remoteIPs(conns))
+	for _, conn := range conns {
+		if hostname, ok := hostnames[conn.RemoteIP]; ok {
+			s.RecordDomain(hostname, "rdns", conn.PID, conn.ProcessName)
+		}
+	}
+	return s.GetTrackerHits(), nil
+}
+
+// GetTrackerHits returns all tracker hits grouped by domain and by process.
+func (s *TrackerService) GetTrackerHits() *TrackerHits {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	hits := &TrackerHits{
+		ByDomain:  make([]TrackerDomainHit, 0, len(s.domains)),
+		ByProcess: make([]TrackerProcessHit, 0, len(s.processes)),
+	}
+	for domain, record := range s.domains {
+		hits.ByDomain = append(hits.ByDomain, record.view(domain))
+	}
+	for _, proc := range s.processes {
+		hits.ByProcess = append(hits.ByProcess, TrackerProcessHit{
+			PID:         proc.pid,
+			ProcessName: proc.name,
+			Domains:     sortedKeys(proc.domains),
+			Lists:       sortedKeys(proc.lists),
+			Count:       proc.count,
+		})
+	}
+	sort.Slice(hits.ByDomain, func(i, j int) bool { return hits.ByDomain[i].Count > hits.ByDomain[j].Count })
+	sort.Slice(hits.ByProcess, func(i, j int) bool { return hits.ByProcess[i].Count > hits.ByProcess[j].Count })
+	return hits
+}
+
+// ClearTrackerHits discards all recorded tracker hits.
+func (s *TrackerService) ClearTrackerHits() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.domains = make(map[string]*trackerDomainRecord)
+	s.processes = make(map[string]*trackerProcessRecord)
+}
+
+// ensureLoaded reads all previously imported blocklists from the config directory once.
+func (s *TrackerService) ensureLoaded() {
+	s.loadOnce.Do(func() {
+		dir, err := appConfigDir(blocklistDirName)
+		if err != nil {
+			log.Printf("WARN: Could not open blocklist directory: %v", err)
+			return
+		}
+		files, err := filepath.Glob(filepath.Join(dir, "*"+blocklistFileExt))
+		if err != nil {
+			log.Printf("WARN: Could not list blocklists: %v", err)
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for _, filePath := range files {
+			name := strings.TrimSuffix(filepath.Base(filePath), blocklistFileExt)
+			list, err := readBlocklist(name, filePath)
+			if err != nil {
+				log.Printf("WARN: Could not load blocklist %s: %v", name, err)
+				continue
+			}
+			s.lists[name] = list
+		}
+	})
+}
+
+func (l *trackerBlocklist) matches(domain string) bool {
+	if _, ok := l.exact[domain]; ok {
+		return true
+	}
+	for d := domain; d != ""; {
+		if _, ok := l.subdomains[d]; ok {
+			return true
+		}
+		i := strings.IndexByte(d, '.')
+		if i < 0 {
+			break
+		}
+		d = d[i+1:]
+	}
+	return false
+}
+
+func (l *trackerBlocklist) info() TrackerList {
+	return TrackerList{
+		Name:        l.name,
+		DomainCount: len(l.exact) + len(l.subdomains),
+		ImportedAt:  l.importedAt.Format(time.RFC3339),
+	}
+}
+
+func (r *trackerDomainRecord) view(domain string) TrackerDomainHit {
+	return TrackerDomainHit{
+		Domain:    domain,
+		Lists:     append([]string(nil), r.lists...),
+		Sources:   sortedKeys(r.sources),
+		Processes: sortedKeys(r.processes),
+		Count:     r.count,
+		FirstSeen: r.firstSeen.Format(time.RFC3339),
+		LastSeen:  r.lastSeen.Format(time.RFC3339),
+	}
+}
+
+func blocklistFilePath(name string) (string, error) {
+	dir, err := appConfigDir(blocklistDirName)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name+blocklistFileExt), nil
+}
+
+// readBlocklist loads a blocklist previously written by writeBlocklist.
+func readBlocklist(name string, filePath string) (*trackerBlocklist, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	list, err := parseBlocklist(name, file)
+	if err != nil {
+		return nil, err
+	}
+	if stat, err := file.Stat(); err == nil {
+		list.importedAt = stat.ModTime()
+	}
+	return list, nil
+}
+
+// writeBlocklist stores the normalized list: plain domains for exact entries and
+// ||domain^ for entries that include subdomains.
+func writeBlocklist(filePath string, list *trackerBlocklist) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Privacy Buddy blocklist %s\n", list.name)
+	for _, domain := range sortedKeys(list.exact) {
+		b.WriteString(domain)
+		b.WriteByte('\n')
+	}
+	for _, domain := range sortedKeys(list.subdomains) {
+		fmt.Fprintf(&b, "||%s^\n", domain)
+	}
+	if err := os.WriteFile(filePath, []byte(b.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write blocklist file: %w", err)
+	}
+	return nil
+}
+
+// parseBlocklist understands hosts files ("0.0.0.0 domain"), plain domain lists
+// and the domain rules of adblock filter lists ("||domain^").
+func parseBlocklist(name string, r io.Reader) (*trackerBlocklist, error) {
+	list := &trackerBlocklist{
+		name:       name,
+		exact:      make(map[string]struct{}),
+		subdomains: make(map[string]struct{}),
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") || strings.HasPrefix(line, "[") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "||") {
+			if domain, ok := parseAdblockRule(line); ok {
+				list.subdomains[domain] = struct{}{}
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "@@") || strings.Contains(line, "##") {
+			continue // Exception and cosmetic rules
+		}
+
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if net.ParseIP(fields[0]) != nil {
+			fields = fields[1:]
+		} else if len(fields) > 1 {
+			continue // Neither a hosts entry nor a plain domain
+		}
+		for _, field := range fields {
+			if domain, ok := normalizeDomain(field); ok && !isLocalHostName(domain) {
+				list.exact[domain] = struct{}{}
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// parseAdblockRule extracts the domain of a "||domain^" rule. Rules with paths,
+// wildcards or site-specific options are skipped.
+func parseAdblockRule(rule string) (string, bool) {
+	rule = strings.TrimPrefix(rule, "||")
+	if i := strings.IndexByte(rule, '$'); i >= 0 {
+		if strings.Contains(rule[i+1:], "domain=") {
+			return "", false
+		}
+		rule = rule[:i]
+	}
+	rule = strings.TrimSuffix(rule, "|")
+	if !strings.HasSuffix(rule, "^") {
+		return "", false
+	}
+	return normalizeDomain(strings.TrimSuffix(rule, "^"))
+}
+
+// normalizeDomain lowercases a domain name, strips the trailing dot and rejects invalid names
+// and IP literals.
+func normalizeDomain(domain string) (string, bool) {
+	domain = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+	if domain == "" || !strings.Contains(domain, ".") || strings.HasPrefix(domain, ".") || net.ParseIP(domain) != nil {
+		return "", false
+	}
+	for _, c := range domain {
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-' || c == '.' || c == '_') {
+			return "", false
+		}
+	}
+	return domain, true
+}
+
+// isLocalHostName reports whether a hosts file entry refers to the machine itself.
+func isLocalHostName(domain string) bool {
+	return domain == "localhost.localdomain" || strings.HasPrefix(domain, "localhost.")
+}
+
+// remoteIPs returns the unique, publicly routable remote IPs of the connections.
+func remoteIPs(conns []NetworkConnection) []string {
+	seen := make(map[string]struct{})
+	var ips []string
+	for _, conn := range conns {
+		ip := net.ParseIP(conn.RemoteIP)
+		if ip == nil || ip.IsUnspecified() || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsMulticast() {
+			continue
+		}
+		if _, ok := seen[conn.RemoteIP]; ok {
+			continue
+		}
+		seen[conn.RemoteIP] = struct{}{}
+		ips = append(ips, conn.RemoteIP)
+	}
+	return ips
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
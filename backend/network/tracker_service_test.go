@@ -0,0 +1,96 @@
+package network
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseBlocklist(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          string
+		wantExact      []string
+		wantSubdomains []string
+	}{
+		{
+			name: "hosts file",
+			input: `# StevenBlack hosts
+127.0.0.1 localhost
+127.0.0.1 localhost.localdomain
+0.0.0.0 0.0.0.0
+0.0.0.0 ads.example.com
+0.0.0.0 Tracker.Example.NET. # trailing comment
+:: ipv6.tracker.example
+`,
+			wantExact: []string{"ads.example.com", "ipv6.tracker.example", "tracker.example.net"},
+		},
+		{
+			name:      "plain domain list",
+			input:     "metrics.example.org\n\nnot a domain\n10.0.0.1\n",
+			wantExact: []string{"metrics.example.org"},
+		},
+		{
+			name: "adblock filter list",
+			input: `[Adblock Plus 2.0]
+! Title: EasyPrivacy
+||doubleclick.net^
+||analytics.example.com^$third-party
+||cdn.example.com^$domain=example.org
+||example.com/track.js
+@@||allowed.example.com^
+example.com##.banner
+`,
+			wantSubdomains: []string{"analytics.example.com", "doubleclick.net"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := parseBlocklist(tt.name, strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("parseBlocklist() error = %v", err)
+			}
+			if got := sortedKeys(list.exact); !reflect.DeepEqual(got, sortedSet(tt.wantExact)) {
+				t.Errorf("exact = %v, want %v", got, tt.wantExact)
+			}
+			if got := sortedKeys(list.subdomains); !reflect.DeepEqual(got, sortedSet(tt.wantSubdomains)) {
+				t.Errorf("subdomains = %v, want %v", got, tt.wantSubdomains)
+			}
+		})
+	}
+}
+
+func TestParseAdblockRule(t *testing.T) {
+	tests := []struct {
+		rule   string
+		want   string
+		wantOK bool
+	}{
+		{"||doubleclick.net^", "doubleclick.net", true},
+		{"||Ads.Example.COM^|", "ads.example.com", true},
+		{"||tracker.example^$third-party,script", "tracker.example", true},
+		{"||tracker.example^$domain=news.example", "", false},
+		{"||example.com/ads/*", "", false},
+		{"||*.example.com^", "", false},
+		{"||192.168.1.1^", "", false},
+		{"||localhost^", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			got, ok := parseAdblockRule(tt.rule)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("parseAdblockRule(%q) = %q, %v; want %q, %v", tt.rule, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func sortedSet(values []string) []string {
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		set[v] = struct{}{}
+	}
+	return sortedKeys(set)
+}
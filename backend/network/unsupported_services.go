@@ -0,0 +1,114 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"time"
+)
+
+// ErrNotSupported is returned by services that have no implementation on the current platform.
+var ErrNotSupported = errors.New("not supported on this platform")
+
+// notSupported wraps ErrNotSupported with the feature and operating system.
+func notSupported(feature string) error {
+	return fmt.Errorf("%s: %w (%s)", feature, ErrNotSupported, runtime.GOOS)
+}
+
+// UnsupportedARPCacheService is used on platforms without an ARP cache implementation.
+type UnsupportedARPCacheService struct{}
+
+// GetARPEntries always returns ErrNotSupported.
+func (UnsupportedARPCacheService) GetARPEntries() ([]ARPEntry, error) {
+	return nil, notSupported("ARP cache")
+}
+
+// UnsupportedNetworkInterfaceService is used on platforms without an interface listing implementation.
+type UnsupportedNetworkInterfaceService struct{}
+
+// ListInterfaces always returns ErrNotSupported.
+func (UnsupportedNetworkInterfaceService) ListInterfaces() ([]NetworkInterface, error) {
+	return nil, notSupported("network interfaces")
+}
+
+// UnsupportedNetworkConnectionService is used on platforms without a connection listing implementation.
+type UnsupportedNetworkConnectionService struct{}
+
+// GetConnections always returns ErrNotSupported.
+func (UnsupportedNetworkConnectionService) GetConnections() ([]NetworkConnection, error) {
+	return nil, notSupported("network connections")
+}
+
+// UnsupportedPacketCaptureService is used on platforms without a packet capture implementation.
+type UnsupportedPacketCaptureService struct{}
+
+// StartCapture always returns ErrNotSupported.
+func (UnsupportedPacketCaptureService) StartCapture(ctx context.Context, interfaceName string, bpfFilter string, duration time.Duration) (<-chan CapturedPacket, error) {
+	return nil, notSupported("packet capture")
+}
+
+// StopCapture always returns ErrNotSupported.
+func (UnsupportedPacketCaptureService) StopCapture() error {
+	return notSupported("packet capture")
+}
+
+// UnsupportedLocalSocketService is used on platforms without Unix domain and raw socket listing.
+type UnsupportedLocalSocketService struct{}
+
+// GetUnixSockets always returns ErrNotSupported.
+func (UnsupportedLocalSocketService) GetUnixSockets() ([]UnixSocket, error) {
+	return nil, notSupported("Unix domain sockets")
+}
+
+// GetRawSockets always returns ErrNotSupported.
+func (UnsupportedLocalSocketService) GetRawSockets() ([]RawSocket, error) {
+	return nil, notSupported("raw sockets")
+}
+
+// UnsupportedWirelessService is used on platforms without a Wi-Fi implementation.
+type UnsupportedWirelessService struct{}
+
+// GetWirelessInfo always returns ErrNotSupported.
+func (UnsupportedWirelessService) GetWirelessInfo() ([]WirelessInfo, error) {
+	return nil, notSupported("Wi-Fi details")
+}
+
+// UnsupportedRoutingService is used on platforms without a routing table implementation.
+type UnsupportedRoutingService struct{}
+
+// GetRoutes always returns ErrNotSupported.
+func (UnsupportedRoutingService) GetRoutes() ([]Route, error) {
+	return nil, notSupported("routing table")
+}
+
+// GetRoutingRules always returns ErrNotSupported.
+func (UnsupportedRoutingService) GetRoutingRules() ([]RoutingRule, error) {
+	return nil, notSupported("routing rules")
+}
+
+// LookupRoute always returns ErrNotSupported.
+func (UnsupportedRoutingService) LookupRoute(destination string) (*RouteLookup, error) {
+	return nil, notSupported("route lookup")
+}
+
+// UnsupportedDNSConfigService is used on platforms without a DNS configuration implementation.
+type UnsupportedDNSConfigService struct{}
+
+// GetDNSConfig always returns ErrNotSupported.
+func (UnsupportedDNSConfigService) GetDNSConfig() (*DNSConfig, error) {
+	return nil, notSupported("DNS configuration")
+}
+
+// UnsupportedNetworkChangeMonitor is used on platforms without change notifications.
+type UnsupportedNetworkChangeMonitor struct{}
+
+// WatchNetworkChanges always returns ErrNotSupported.
+func (UnsupportedNetworkChangeMonitor) WatchNetworkChanges(ctx context.Context) (<-chan NetworkChange, error) {
+	return nil, notSupported("network change monitoring")
+}
+
+// WatchDNSChanges always returns ErrNotSupported.
+func (UnsupportedNetworkChangeMonitor) WatchDNSChanges(ctx context.Context) (<-chan struct{}, error) {
+	return nil, notSupported("DNS change monitoring")
+}
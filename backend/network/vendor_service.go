@@ -0,0 +1,113 @@
+package network
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"privacy-buddy/backend/network/oui"
+)
+
+const ouiDirName = "oui"
+
+// Singleton instance (thread-safe)
+var (
+	vendorInstance *VendorService
+	vendorOnce     sync.Once
+)
+
+// MACVendor describes the vendor of a MAC address.
+type MACVendor struct {
+	MACAddress          string `json:"MACAddress"`
+	Vendor              string `json:"Vendor"`
+	LocallyAdministered bool   `json:"LocallyAdministered"` // Randomized or otherwise not IEEE-assigned
+	Multicast           bool   `json:"Multicast"`
+}
+
+// VendorService resolves MAC addresses to vendors using the built-in IEEE registry data
+// and any registry files imported into the config directory.
+type VendorService struct {
+	registry *oui.Registry
+}
+
+// GetVendorService returns the shared VendorService instance.
+func GetVendorService() *VendorService {
+	vendorOnce.Do(func() {
+		vendorInstance = &VendorService{registry: oui.Default()}
+		vendorInstance.loadImportedRegistries()
+	})
+	return vendorInstance
+}
+
+// LookupVendor returns the vendor information of a MAC address.
+func (s *VendorService) LookupVendor(macAddress string) MACVendor {
+	result := MACVendor{MACAddress: macAddress}
+	mac, err := net.ParseMAC(macAddress)
+	if err != nil {
+		return result
+	}
+	result.Vendor = s.registry.Lookup(mac)
+	result.LocallyAdministered = oui.IsLocallyAdministered(mac)
+	result.Multicast = oui.IsMulticast(mac)
+	return result
+}
+
+// ImportOUIRegistry updates the built-in registry with a newer IEEE registry CSV file
+// (oui.csv, mam.csv or oui36.csv). The file is stored in the config directory under the
+// IEEE name of its registry, replacing an earlier import, so it is loaded again on the next start.
+func (s *VendorService) ImportOUIRegistry(sourcePath string) (int, error) {
+	data, err := os.ReadFile(sourcePath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read OUI registry %s: %w", sourcePath, err)
+	}
+
+	fileName, err := oui.RegistryFileName(bytes.NewReader(data))
+	if err != nil {
+		return 0, fmt.Errorf("invalid OUI registry %s: %w", sourcePath, err)
+	}
+
+	dir, err := appConfigDir(ouiDirName)
+	if err != nil {
+		return 0, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, fileName), data, 0644); err != nil {
+		return 0, fmt.Errorf("failed to store OUI registry: %w", err)
+	}
+
+	count, err := s.registry.LoadCSV(bytes.NewReader(data))
+	if err != nil {
+		return 0, err
+	}
+	log.Printf("Imported %d OUI assignments from %s", count, sourcePath)
+	return count, nil
+}
+
+// GetOUIRegistrySize returns the number of known vendor assignments.
+func (s *VendorService) GetOUIRegistrySize() int {
+	return s.registry.Size()
+}
+
+// loadImportedRegistries merges all registry files from the config directory.
+func (s *VendorService) loadImportedRegistries() {
+	dir, err := appConfigDir(ouiDirName)
+	if err != nil {
+		log.Printf("WARN: Could not open OUI directory: %v", err)
+		return
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "*.csv"))
+	for _, filePath := range files {
+		file, err := os.Open(filePath)
+		if err != nil {
+			log.Printf("WARN: Could not open OUI registry %s: %v", filePath, err)
+			continue
+		}
+		if _, err := s.registry.LoadCSV(file); err != nil {
+			log.Printf("WARN: Could not load OUI registry %s: %v", filePath, err)
+		}
+		file.Close()
+	}
+}
@@ -0,0 +1,6 @@
+package network
+
+// WirelessService defines the interface for reading the Wi-Fi connection of wireless interfaces.
+type WirelessService interface {
+	GetWirelessInfo() ([]WirelessInfo, error)
+}
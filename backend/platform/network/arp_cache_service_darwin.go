@@ -11,9 +11,8 @@ import (
 // DarwinARPCacheService provides macOS-specific implementation for reading the ARP cache.
 type DarwinARPCacheService struct{}
 
-// GetARPEntries reads the ARP cache on macOS using gopsutil.
-// ArpEntries gibt strukturierte ARP-Einträge für macOS zurück
-func ArpEntries() ([]anynetwork.ARPEntry, error) {
+// GetARPEntries reads the ARP cache on macOS by parsing the output of "arp -a".
+func (s *DarwinARPCacheService) GetARPEntries() ([]anynetwork.ARPEntry, error) {
 	out, err := exec.Command("arp", "-a").Output()
 	if err != nil {
 		return nil, err
@@ -22,20 +21,32 @@ func ArpEntries() ([]anynetwork.ARPEntry, error) {
 	var entries []anynetwork.ARPEntry
 	lines := strings.Split(string(out), "\n")
 	for _, line := range lines {
-		// rudimentäres Beispiel: "(192.168.0.1) at 4c:66:41:xx:xx:xx on en0 ifscope [ethernet]"
+		// e.g. "? (192.168.0.1) at 4c:66:41:xx:xx:xx on en0 ifscope [ethernet]"
 		parts := strings.Fields(line)
-		if len(parts) >= 4 {
-			ip := strings.Trim(parts[0], "()")
-			mac := parts[3]
-			iface := parts[5]
-			entries = append(entries, anynetwork.ARPEntry{
-				IPAddress:  ip,
-				MACAddress: mac,
-				Interface:  iface,
-				Type:       "ethernet", // oder aus parts[len(parts)-1]
-			})
+		if len(parts) < 6 || parts[2] != "at" || parts[4] != "on" {
+			continue
 		}
+		mac := parts[3]
+		if mac == "(incomplete)" {
+			continue
+		}
+		entryType := "dynamic"
+		if strings.Contains(line, "permanent") {
+			entryType = "static"
+		}
+		entries = append(entries, anynetwork.ARPEntry{
+			IPAddress:  strings.Trim(parts[1], "()"),
+			MACAddress: mac,
+			Interface:  parts[5],
+			Type:       entryType,
+			Family:     "IPv4",
+		})
 	}
 
 	return entries, nil
 }
+
+// NewARPCacheService creates the Darwin implementation of ARPCacheService.
+func NewARPCacheService() anynetwork.ARPCacheService {
+	return &DarwinARPCacheService{}
+}
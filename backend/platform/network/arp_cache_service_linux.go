@@ -4,18 +4,126 @@ package network
 
 import (
 	"bufio"
+	"encoding/binary"
 	"fmt"
+	"log"
+	"net"
 	"os"
+	"strconv"
 	"strings"
 
+	"golang.org/x/sys/unix"
+
 	anynetwork "privacy-buddy/backend/network"
 )
 
+// Flags of the /proc/net/arp flags column (see include/uapi/linux/if_arp.h).
+const (
+	arpFlagCompleted = 0x02 // ATF_COM
+	arpFlagPermanent = 0x04 // ATF_PERM
+)
+
 // LinuxARPCacheService provides Linux-specific implementation for reading the ARP cache.
 type LinuxARPCacheService struct{}
 
-// GetARPEntries reads the ARP cache from /proc/net/arp on Linux.
+// GetARPEntries returns the IPv4 (ARP) and IPv6 (NDP) neighbor tables read via netlink.
+// If netlink is unavailable it falls back to the IPv4-only /proc/net/arp.
 func (s *LinuxARPCacheService) GetARPEntries() ([]anynetwork.ARPEntry, error) {
+	entries, err := readNeighborsNetlink()
+	if err == nil {
+		return entries, nil
+	}
+	log.Printf("WARN: netlink neighbor dump failed, falling back to /proc/net/arp: %v", err)
+	return readProcNetARP()
+}
+
+// readNeighborsNetlink dumps the neighbor tables of all address families with RTM_GETNEIGH.
+func readNeighborsNetlink() ([]anynetwork.ARPEntry, error) {
+	request := make([]byte, unix.SizeofNdMsg)
+	request[0] = unix.AF_UNSPEC
+
+	messages, err := netlinkDump(unix.NETLINK_ROUTE, unix.RTM_GETNEIGH, request)
+	if err != nil {
+		return nil, err
+	}
+
+	interfaceName := interfaceNamesByIndex()
+	var entries []anynetwork.ARPEntry
+	for _, m := range messages {
+		if m.Header.Type != unix.RTM_NEWNEIGH || len(m.Data) < unix.SizeofNdMsg {
+			continue
+		}
+		family := m.Data[0]
+		if family != unix.AF_INET && family != unix.AF_INET6 {
+			continue // e.g., AF_BRIDGE forwarding database entries
+		}
+		ifIndex := int(int32(binary.NativeEndian.Uint32(m.Data[4:8])))
+		state := binary.NativeEndian.Uint16(m.Data[8:10])
+		flags := m.Data[10]
+
+		attrs := netlinkAttributeMap(m.Data[unix.SizeofNdMsg:])
+		ip := net.IP(attrs[unix.NDA_DST])
+		if len(ip) == 0 || ip.IsUnspecified() || ip.IsMulticast() {
+			continue
+		}
+		mac := ""
+		if lladdr := attrs[unix.NDA_LLADDR]; len(lladdr) > 0 {
+			mac = net.HardwareAddr(lladdr).String()
+		}
+
+		entryType := "dynamic"
+		if state&(unix.NUD_PERMANENT|unix.NUD_NOARP) != 0 {
+			entryType = "static"
+		}
+		familyName := "IPv4"
+		if family == unix.AF_INET6 {
+			familyName = "IPv6"
+		}
+
+		entries = append(entries, anynetwork.ARPEntry{
+			IPAddress:      ip.String(),
+			MACAddress:     mac,
+			Interface:      interfaceName(ifIndex),
+			Type:           entryType,
+			Family:         familyName,
+			State:          neighborStateName(state),
+			IsRouter:       flags&unix.NTF_ROUTER != 0,
+			InterfaceIndex: ifIndex,
+		})
+	}
+	return entries, nil
+}
+
+// neighborStateName converts a NUD_* state into its name as shown by "ip neigh".
+func neighborStateName(state uint16) string {
+	names := []struct {
+		flag uint16
+		name string
+	}{
+		{unix.NUD_INCOMPLETE, "INCOMPLETE"},
+		{unix.NUD_REACHABLE, "REACHABLE"},
+		{unix.NUD_STALE, "STALE"},
+		{unix.NUD_DELAY, "DELAY"},
+		{unix.NUD_PROBE, "PROBE"},
+		{unix.NUD_FAILED, "FAILED"},
+		{unix.NUD_NOARP, "NOARP"},
+		{unix.NUD_PERMANENT, "PERMANENT"},
+	}
+
+	var states []string
+	for _, n := range names {
+		if state&n.flag != 0 {
+			states = append(states, n.name)
+		}
+	}
+	if len(states) == 0 {
+		return "NONE"
+	}
+	return strings.Join(states, ",")
+}
+
+// readProcNetARP reads the IPv4 ARP cache from /proc/net/arp.
+func readProcNetARP() ([]anynetwork.ARPEntry, error) {
 	file, err := os.Open("/proc/net/arp")
 	if err != nil {
 		return nil, fmt.Errorf("failed to open /proc/net/arp: %w", err)
@@ -35,19 +143,35 @@ func (s *LinuxARPCacheService) GetARPEntries() ([]anynetwork.ARPEntry, error) {
 
 		ipAddress := fields[0]
 		macAddress := fields[3]
-		flags := fields[2] // Flags field contains type information
 		device := fields[5]
 
+		flags, err := strconv.ParseUint(fields[2], 0, 32)
+		if err != nil {
+			continue
+		}
+
 		entryType := "dynamic"
-		if strings.Contains(flags, "0x2") { // AT_PERM flag for static entries
+		state := "REACHABLE"
+		if flags&arpFlagPermanent != 0 {
 			entryType = "static"
+			state = "PERMANENT"
+		} else if flags&arpFlagCompleted == 0 {
+			state = "INCOMPLETE"
+		}
+
+		ifIndex := 0
+		if iface, err := net.InterfaceByName(device); err == nil {
+			ifIndex = iface.Index
 		}
 
 		entries = append(entries, anynetwork.ARPEntry{
-			IPAddress:  ipAddress,
-			MACAddress: macAddress,
-			Interface:  device,
-			Type:       entryType,
+			IPAddress:      ipAddress,
+			MACAddress:     macAddress,
+			Interface:      device,
+			Type:           entryType,
+			Family:         "IPv4",
+			State:          state,
+			InterfaceIndex: ifIndex,
 		})
 	}
 
@@ -58,4 +182,7 @@ func (s *LinuxARPCacheService) GetARPEntries() ([]anynetwork.ARPEntry, error) {
 	return entries, nil
 }
 
-
+// NewARPCacheService creates the Linux implementation of ARPCacheService.
+func NewARPCacheService() anynetwork.ARPCacheService {
+	return &LinuxARPCacheService{}
+}
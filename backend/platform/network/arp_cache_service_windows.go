@@ -35,4 +35,7 @@ func (s *WindowsARPCacheService) GetARPEntries() ([]anynetwork.ARPEntry, error)
 	return entries, nil
 }
 
-
+// NewARPCacheService creates the Windows implementation of ARPCacheService.
+func NewARPCacheService() anynetwork.ARPCacheService {
+	return &WindowsARPCacheService{}
+}
@@ -0,0 +1,125 @@
+//go:build linux
+
+package network
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// resolvedRuntimeDir holds the files systemd-resolved rewrites whenever its servers or
+// domains change, including the per-link configuration set via D-Bus.
+const resolvedRuntimeDir = "/run/systemd/resolve"
+
+// dnsWatchMask selects the events of a file being written, replaced or removed. Resolver
+// files are usually replaced by renaming a temporary file or by changing a symlink.
+const dnsWatchMask = unix.IN_CLOSE_WRITE | unix.IN_MOVED_TO | unix.IN_MOVED_FROM | unix.IN_CREATE | unix.IN_DELETE
+
+// WatchDNSChanges watches /etc/resolv.conf, the target of its symlink and the runtime files of
+// systemd-resolved with inotify. Directories are watched instead of the files, so that the
+// watch survives the files being replaced.
+func (m *LinuxNetworkChangeMonitor) WatchDNSChanges(ctx context.Context) (<-chan struct{}, error) {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize inotify: %w", err)
+	}
+
+	// Watched directory -> file names of interest; an empty set matches every file.
+	watched := make(map[string]map[string]bool)
+	addFile := func(path string) {
+		dir, name := filepath.Split(path)
+		dir = filepath.Clean(dir)
+		if watched[dir] == nil {
+			watched[dir] = make(map[string]bool)
+		}
+		watched[dir][name] = true
+	}
+	addFile(resolvConfPath)
+	if target, err := filepath.EvalSymlinks(resolvConfPath); err == nil && target != resolvConfPath {
+		addFile(target) // e.g., /run/NetworkManager/resolv.conf or /run/resolvconf/resolv.conf
+	}
+	watched[resolvedRuntimeDir] = map[string]bool{}
+
+	names := make(map[int32]map[string]bool)
+	for dir, files := range watched {
+		wd, err := unix.InotifyAddWatch(fd, dir, dnsWatchMask)
+		if err != nil {
+			if !errors.Is(err, unix.ENOENT) {
+				log.Printf("WARN: Could not watch %s: %v", dir, err)
+			}
+			continue
+		}
+		names[int32(wd)] = files
+	}
+	if len(names) == 0 {
+		unix.Close(fd)
+		return nil, fmt.Errorf("no resolver configuration directory could be watched")
+	}
+
+	notifications := make(chan struct{}, 1)
+	go func() {
+		defer close(notifications)
+		defer unix.Close(fd)
+
+		buf := make([]byte, os.Getpagesize())
+		pollFds := []unix.PollFd{{Fd: int32(fd), Events: unix.POLLIN}}
+		for ctx.Err() == nil {
+			// The timeout lets the loop notice the cancelled context.
+			if n, err := unix.Poll(pollFds, 1000); err != nil || n == 0 {
+				if err != nil && !errors.Is(err, unix.EINTR) {
+					log.Printf("WARN: DNS configuration watch stopped: %v", err)
+					return
+				}
+				continue
+			}
+			n, err := unix.Read(fd, buf)
+			if err != nil {
+				if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EINTR) {
+					continue
+				}
+				log.Printf("WARN: DNS configuration watch stopped: %v", err)
+				return
+			}
+			if !inotifyEventsMatch(buf[:n], names) {
+				continue
+			}
+			select {
+			case notifications <- struct{}{}:
+			default: // A signal is already pending
+			}
+		}
+	}()
+	return notifications, nil
+}
+
+// inotifyEventsMatch reports whether one of the events concerns a watched file name.
+func inotifyEventsMatch(data []byte, names map[int32]map[string]bool) bool {
+	for len(data) >= unix.SizeofInotifyEvent {
+		// struct inotify_event: wd, mask, cookie, len and the NUL-padded name
+		wd := int32(binary.NativeEndian.Uint32(data[0:4]))
+		mask := binary.NativeEndian.Uint32(data[4:8])
+		end := unix.SizeofInotifyEvent + int(binary.NativeEndian.Uint32(data[12:16]))
+		if end > len(data) {
+			return false
+		}
+		name := strings.TrimRight(string(data[unix.SizeofInotifyEvent:end]), "\x00")
+		data = data[end:]
+
+		if mask&unix.IN_Q_OVERFLOW != 0 {
+			return true
+		}
+		files, ok := names[wd]
+		if ok && (len(files) == 0 || files[name]) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,333 @@
+//go:build linux
+
+package network
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	anynetwork "privacy-buddy/backend/network"
+)
+
+const (
+	resolvConfPath       = "/etc/resolv.conf"
+	resolvedUpstreamConf = "/run/systemd/resolve/resolv.conf"
+	nsswitchConfPath     = "/etc/nsswitch.conf"
+	hostsFilePath        = "/etc/hosts"
+	resolvectlTimeout    = 5 * time.Second
+)
+
+var (
+	// resolvectlKeyPattern matches "Key: value" lines; IPv6 continuation lines do not match
+	// because their colons are not followed by a space.
+	resolvectlKeyPattern  = regexp.MustCompile(`^\s*([A-Za-z][A-Za-z0-9 ./-]*?):(?:\s+(.*))?$`)
+	resolvectlLinkPattern = regexp.MustCompile(`^Link (\d+) \(([^)]+)\)`)
+)
+
+// LinuxDNSConfigService reads the resolver configuration from the configuration files and systemd-resolved.
+type LinuxDNSConfigService struct{}
+
+// GetDNSConfig parses /etc/resolv.conf, /etc/nsswitch.conf and /etc/hosts and, if available,
+// the per-link configuration of systemd-resolved from "resolvectl status". Files that cannot
+// be read are left out, so a missing /etc/resolv.conf still yields the remaining configuration.
+func (s *LinuxDNSConfigService) GetDNSConfig() (*anynetwork.DNSConfig, error) {
+	return readDNSConfig(resolvConfPath, nsswitchConfPath, hostsFilePath), nil
+}
+
+// readDNSConfig combines the configuration files at the given paths with systemd-resolved.
+func readDNSConfig(resolvPath, nsswitchPath, hostsPath string) *anynetwork.DNSConfig {
+	resolvConf, err := readResolvConf(resolvPath)
+	if err != nil {
+		log.Printf("WARN: Could not read resolver configuration: %v", err)
+		resolvConf = anynetwork.ResolvConf{Path: resolvPath, SymlinkTarget: resolvConf.SymlinkTarget, Error: err.Error()}
+	}
+	config := &anynetwork.DNSConfig{
+		ResolvConf:    resolvConf,
+		NSSwitchHosts: readNSSwitchHosts(nsswitchPath),
+	}
+	config.HostsEntries, config.HostsBlockedCount = readHostsFile(hostsPath)
+
+	// Without the local stub, glibc sends queries to the resolv.conf name servers directly.
+	if resolvConf.Error == "" && !resolvConf.IsLocalStub {
+		config.Resolvers = append(config.Resolvers, anynetwork.DNSResolverConfig{
+			Scope:        "global",
+			Source:       "resolv.conf",
+			Servers:      resolvConf.Nameservers,
+			Domains:      resolvConf.SearchDomains,
+			DefaultRoute: true,
+		})
+	}
+
+	resolvers, err := readResolvedStatus()
+	switch {
+	case err == nil:
+		config.ResolvedActive = true
+		config.Resolvers = append(config.Resolvers, resolvers...)
+	case resolvConf.ManagedBy == "systemd-resolved":
+		// resolvectl is missing or failed; the upstream file still lists the servers.
+		if upstream, upstreamErr := readResolvConf(resolvedUpstreamConf); upstreamErr == nil {
+			config.ResolvedActive = true
+			config.Resolvers = append(config.Resolvers, anynetwork.DNSResolverConfig{
+				Scope:        "global",
+				Source:       "systemd-resolved",
+				Servers:      upstream.Nameservers,
+				Domains:      upstream.SearchDomains,
+				DefaultRoute: true,
+			})
+		}
+	}
+	return config
+}
+
+// NewDNSConfigService creates the Linux implementation of DNSConfigService.
+func NewDNSConfigService() anynetwork.DNSConfigService {
+	return &LinuxDNSConfigService{}
+}
+
+// readResolvConf parses a resolv.conf file and detects the software that manages it.
+func readResolvConf(path string) (anynetwork.ResolvConf, error) {
+	conf := anynetwork.ResolvConf{Path: path}
+	if target, err := filepath.EvalSymlinks(path); err == nil && target != path {
+		conf.SymlinkTarget = target
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return conf, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var comments strings.Builder
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			comments.WriteString(line + "\n")
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "nameserver":
+			conf.Nameservers = append(conf.Nameservers, fields[1])
+		case "search", "domain":
+			// The last search or domain line wins
+			conf.SearchDomains = fields[1:]
+		case "options":
+			conf.Options = append(conf.Options, fields[1:]...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return conf, fmt.Errorf("error reading %s: %w", path, err)
+	}
+
+	conf.ManagedBy = resolvConfManager(conf.SymlinkTarget, comments.String())
+	if len(conf.Nameservers) > 0 {
+		conf.IsLocalStub = true
+		for _, server := range conf.Nameservers {
+			ip := net.ParseIP(strings.SplitN(server, "%", 2)[0])
+			conf.IsLocalStub = conf.IsLocalStub && ip != nil && ip.IsLoopback()
+		}
+		if conf.IsLocalStub {
+			conf.StubAddress = conf.Nameservers[0]
+		}
+	}
+	return conf, nil
+}
+
+// resolvConfManager guesses the manager of resolv.conf from its symlink target and header comments.
+func resolvConfManager(target string, comments string) string {
+	switch {
+	case strings.Contains(target, "/systemd/resolve/") || strings.Contains(comments, "systemd-resolved"):
+		return "systemd-resolved"
+	case strings.Contains(comments, "NetworkManager"):
+		return "NetworkManager"
+	case strings.Contains(target, "/resolvconf/") || strings.Contains(comments, "resolvconf"):
+		return "resolvconf"
+	case strings.Contains(comments, "netconfig"):
+		return "netconfig"
+	}
+	return ""
+}
+
+// readNSSwitchHosts returns the sources of the "hosts:" database, including action items like "[NOTFOUND=return]".
+func readNSSwitchHosts(path string) []string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line, _, _ = strings.Cut(line, "#")
+		if sources, ok := strings.CutPrefix(strings.TrimSpace(line), "hosts:"); ok {
+			return strings.Fields(sources)
+		}
+	}
+	return nil
+}
+
+// readHostsFile parses a hosts file. Entries mapping names to the unspecified address are
+// blocklist entries; they are only counted.
+func readHostsFile(path string) ([]anynetwork.HostsEntry, int) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, 0
+	}
+	var entries []anynetwork.HostsEntry
+	blocked := 0
+	for _, line := range strings.Split(string(data), "\n") {
+		line, _, _ = strings.Cut(line, "#")
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		ip := net.ParseIP(strings.SplitN(fields[0], "%", 2)[0])
+		if ip == nil {
+			continue
+		}
+		if ip.IsUnspecified() {
+			blocked += len(fields) - 1
+			continue
+		}
+		entries = append(entries, anynetwork.HostsEntry{IPAddress: fields[0], Hostnames: fields[1:]})
+	}
+	return entries, blocked
+}
+
+// readResolvedStatus runs "resolvectl status" (or "systemd-resolve --status" on systemd
+// versions before 239) and parses the global and per-link configuration.
+func readResolvedStatus() ([]anynetwork.DNSResolverConfig, error) {
+	var lastErr error
+	for _, command := range [][]string{{"resolvectl", "status", "--no-pager"}, {"systemd-resolve", "--status", "--no-pager"}} {
+		ctx, cancel := context.WithTimeout(context.Background(), resolvectlTimeout)
+		out, err := exec.CommandContext(ctx, command[0], command[1:]...).Output()
+		cancel()
+		if err != nil {
+			lastErr = fmt.Errorf("%s failed: %w", command[0], err)
+			continue
+		}
+		return parseResolvectlStatus(string(out)), nil
+	}
+	return nil, lastErr
+}
+
+// parseResolvectlStatus parses both the compact "Protocols:" format (systemd 246+) and the
+// older one with a line per setting. Links without DNS servers or domains are skipped.
+func parseResolvectlStatus(output string) []anynetwork.DNSResolverConfig {
+	var resolvers []anynetwork.DNSResolverConfig
+	var current *anynetwork.DNSResolverConfig
+	lastKey := ""
+
+	flush := func() {
+		if current != nil && (current.Scope == "global" || len(current.Servers) > 0 || len(current.Domains) > 0) {
+			resolvers = append(resolvers, *current)
+		}
+		current = nil
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if line == "Global" {
+			flush()
+			current = &anynetwork.DNSResolverConfig{Scope: "global", Source: "systemd-resolved", DefaultRoute: true}
+			lastKey = ""
+			continue
+		}
+		if m := resolvectlLinkPattern.FindStringSubmatch(line); m != nil {
+			flush()
+			index, _ := strconv.Atoi(m[1])
+			current = &anynetwork.DNSResolverConfig{Scope: m[2], InterfaceIndex: index, Source: "systemd-resolved"}
+			lastKey = ""
+			continue
+		}
+		if current == nil {
+			continue
+		}
+
+		key, value := lastKey, strings.TrimSpace(line)
+		if m := resolvectlKeyPattern.FindStringSubmatch(line); m != nil {
+			key, value = m[1], m[2]
+			lastKey = key
+		}
+		applyResolvectlSetting(current, key, strings.Fields(value))
+	}
+	flush()
+	return resolvers
+}
+
+// applyResolvectlSetting stores one (possibly continued) "Key: value" line of resolvectl.
+func applyResolvectlSetting(resolver *anynetwork.DNSResolverConfig, key string, values []string) {
+	first := ""
+	if len(values) > 0 {
+		first = values[0]
+	}
+	switch key {
+	case "DNS Servers":
+		resolver.Servers = append(resolver.Servers, values...)
+	case "Fallback DNS Servers":
+		resolver.FallbackServers = append(resolver.FallbackServers, values...)
+	case "DNS Domain":
+		resolver.Domains = append(resolver.Domains, values...)
+	case "Current DNS Server":
+		resolver.CurrentServer = first
+	case "DefaultRoute setting", "Default Route":
+		resolver.DefaultRoute = first == "yes"
+	case "DNSSEC setting":
+		resolver.DNSSEC = first
+	case "DNSSEC supported":
+		resolver.DNSSECSupported = first == "yes"
+	case "DNSOverTLS setting":
+		resolver.DNSOverTLS = first
+	case "LLMNR setting":
+		resolver.LLMNR = first
+	case "MulticastDNS setting":
+		resolver.MulticastDNS = first
+	case "Protocols":
+		for _, protocol := range values {
+			applyResolvectlProtocol(resolver, protocol)
+		}
+	}
+}
+
+// applyResolvectlProtocol parses an item of the "Protocols:" line, e.g., "+DefaultRoute",
+// "-mDNS", "DNSOverTLS=opportunistic" or "DNSSEC=allow-downgrade/supported".
+func applyResolvectlProtocol(resolver *anynetwork.DNSResolverConfig, protocol string) {
+	name, value, hasValue := strings.Cut(protocol, "=")
+	if !hasValue {
+		switch {
+		case strings.HasPrefix(protocol, "+"):
+			name, value = protocol[1:], "yes"
+		case strings.HasPrefix(protocol, "-"):
+			name, value = protocol[1:], "no"
+		default:
+			return
+		}
+	}
+	switch name {
+	case "DefaultRoute":
+		resolver.DefaultRoute = value == "yes"
+	case "LLMNR":
+		resolver.LLMNR = value
+	case "mDNS":
+		resolver.MulticastDNS = value
+	case "DNSOverTLS":
+		resolver.DNSOverTLS = value
+	case "DNSSEC":
+		setting, support, _ := strings.Cut(value, "/")
+		resolver.DNSSEC = setting
+		resolver.DNSSECSupported = support == "supported"
+	}
+}
@@ -0,0 +1,238 @@
+//go:build linux
+
+package network
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	anynetwork "privacy-buddy/backend/network"
+)
+
+// resolvectlStatusLegacy is "systemd-resolve --status" output of systemd 239 with a line per setting.
+const resolvectlStatusLegacy = `Global
+       LLMNR setting: no
+MulticastDNS setting: no
+  DNSOverTLS setting: no
+      DNSSEC setting: no
+    DNSSEC supported: no
+  Current DNS Server: 192.168.1.1
+         DNS Servers: 192.168.1.1
+                      2001:db8::53
+Fallback DNS Servers: 1.1.1.1
+                      2606:4700:4700::1111
+          DNSSEC NTA: 10.in-addr.arpa
+                      home
+                      local
+
+Link 3 (wlp2s0)
+      Current Scopes: DNS LLMNR/IPv4
+DefaultRoute setting: yes
+       LLMNR setting: yes
+MulticastDNS setting: no
+  DNSOverTLS setting: opportunistic
+      DNSSEC setting: allow-downgrade
+    DNSSEC supported: yes
+  Current DNS Server: fd00::1
+         DNS Servers: fd00::1
+                      fe80::ca0e:14ff:fe2b:1
+                      192.168.1.1
+          DNS Domain: ~.
+                      fritz.box
+
+Link 2 (enp0s31f6)
+      Current Scopes: none
+DefaultRoute setting: no
+       LLMNR setting: yes
+MulticastDNS setting: no
+  DNSOverTLS setting: no
+      DNSSEC setting: no
+    DNSSEC supported: no
+`
+
+// resolvectlStatusCompact is "resolvectl status" output of systemd 252 with the "Protocols:" line.
+const resolvectlStatusCompact = `Global
+           Protocols: -LLMNR -mDNS +DNSOverTLS DNSSEC=no/unsupported
+    resolv.conf mode: stub
+  Current DNS Server: 9.9.9.9#dns.quad9.net
+         DNS Servers: 9.9.9.9#dns.quad9.net
+                      2620:fe::fe#dns.quad9.net
+Fallback DNS Servers: 1.1.1.1#cloudflare-dns.com
+                      2606:4700:4700::1111#cloudflare-dns.com
+
+Link 2 (enp0s31f6)
+    Current Scopes: DNS
+         Protocols: +DefaultRoute -LLMNR -mDNS DNSOverTLS=opportunistic DNSSEC=allow-downgrade/supported
+Current DNS Server: 192.168.178.1
+       DNS Servers: 192.168.178.1
+                    fd00::ca0e:14ff:fe2b:1
+        DNS Domain: fritz.box
+
+Link 3 (wlan0)
+    Current Scopes: none
+         Protocols: -DefaultRoute -LLMNR -mDNS -DNSOverTLS DNSSEC=no/unsupported
+
+Link 5 (wg0)
+Current Scopes: DNS
+     Protocols: -DefaultRoute +LLMNR -mDNS -DNSOverTLS DNSSEC=no/unsupported
+   DNS Servers: 10.8.0.1
+    DNS Domain: ~corp.example
+`
+
+func TestParseResolvectlStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   []anynetwork.DNSResolverConfig
+	}{
+		{
+			name:   "legacy format",
+			output: resolvectlStatusLegacy,
+			want: []anynetwork.DNSResolverConfig{
+				{
+					Scope:           "global",
+					Source:          "systemd-resolved",
+					Servers:         []string{"192.168.1.1", "2001:db8::53"},
+					CurrentServer:   "192.168.1.1",
+					FallbackServers: []string{"1.1.1.1", "2606:4700:4700::1111"},
+					DefaultRoute:    true,
+					DNSSEC:          "no",
+					DNSOverTLS:      "no",
+					LLMNR:           "no",
+					MulticastDNS:    "no",
+				},
+				{
+					Scope:           "wlp2s0",
+					InterfaceIndex:  3,
+					Source:          "systemd-resolved",
+					Servers:         []string{"fd00::1", "fe80::ca0e:14ff:fe2b:1", "192.168.1.1"},
+					CurrentServer:   "fd00::1",
+					Domains:         []string{"~.", "fritz.box"},
+					DefaultRoute:    true,
+					DNSSEC:          "allow-downgrade",
+					DNSSECSupported: true,
+					DNSOverTLS:      "opportunistic",
+					LLMNR:           "yes",
+					MulticastDNS:    "no",
+				},
+			},
+		},
+		{
+			name:   "compact format",
+			output: resolvectlStatusCompact,
+			want: []anynetwork.DNSResolverConfig{
+				{
+					Scope:           "global",
+					Source:          "systemd-resolved",
+					Servers:         []string{"9.9.9.9#dns.quad9.net", "2620:fe::fe#dns.quad9.net"},
+					CurrentServer:   "9.9.9.9#dns.quad9.net",
+					FallbackServers: []string{"1.1.1.1#cloudflare-dns.com", "2606:4700:4700::1111#cloudflare-dns.com"},
+					DefaultRoute:    true,
+					DNSSEC:          "no",
+					DNSOverTLS:      "yes",
+					LLMNR:           "no",
+					MulticastDNS:    "no",
+				},
+				{
+					Scope:           "enp0s31f6",
+					InterfaceIndex:  2,
+					Source:          "systemd-resolved",
+					Servers:         []string{"192.168.178.1", "fd00::ca0e:14ff:fe2b:1"},
+					CurrentServer:   "192.168.178.1",
+					Domains:         []string{"fritz.box"},
+					DefaultRoute:    true,
+					DNSSEC:          "allow-downgrade",
+					DNSSECSupported: true,
+					DNSOverTLS:      "opportunistic",
+					LLMNR:           "no",
+					MulticastDNS:    "no",
+				},
+				{
+					Scope:          "wg0",
+					InterfaceIndex: 5,
+					Source:         "systemd-resolved",
+					Servers:        []string{"10.8.0.1"},
+					Domains:        []string{"~corp.example"},
+					DNSSEC:         "no",
+					DNSOverTLS:     "no",
+					LLMNR:          "yes",
+					MulticastDNS:   "no",
+				},
+			},
+		},
+		{
+			name:   "empty output",
+			output: "",
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseResolvectlStatus(tt.output)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseResolvectlStatus() =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyResolvectlProtocol(t *testing.T) {
+	tests := []struct {
+		protocol string
+		want     anynetwork.DNSResolverConfig
+	}{
+		{"+DefaultRoute", anynetwork.DNSResolverConfig{DefaultRoute: true}},
+		{"-DefaultRoute", anynetwork.DNSResolverConfig{}},
+		{"+LLMNR", anynetwork.DNSResolverConfig{LLMNR: "yes"}},
+		{"-mDNS", anynetwork.DNSResolverConfig{MulticastDNS: "no"}},
+		{"mDNS=resolve", anynetwork.DNSResolverConfig{MulticastDNS: "resolve"}},
+		{"+DNSOverTLS", anynetwork.DNSResolverConfig{DNSOverTLS: "yes"}},
+		{"DNSOverTLS=opportunistic", anynetwork.DNSResolverConfig{DNSOverTLS: "opportunistic"}},
+		{"DNSSEC=allow-downgrade/supported", anynetwork.DNSResolverConfig{DNSSEC: "allow-downgrade", DNSSECSupported: true}},
+		{"DNSSEC=yes/unsupported", anynetwork.DNSResolverConfig{DNSSEC: "yes"}},
+		{"DNSSEC=no", anynetwork.DNSResolverConfig{DNSSEC: "no"}},
+		{"LLMNR", anynetwork.DNSResolverConfig{}},
+		{"+Unknown", anynetwork.DNSResolverConfig{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.protocol, func(t *testing.T) {
+			var got anynetwork.DNSResolverConfig
+			applyResolvectlProtocol(&got, tt.protocol)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("applyResolvectlProtocol(%q) = %+v, want %+v", tt.protocol, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadDNSConfigWithoutResolvConf(t *testing.T) {
+	dir := t.TempDir()
+	hostsPath := filepath.Join(dir, "hosts")
+	nsswitchPath := filepath.Join(dir, "nsswitch.conf")
+	if err := os.WriteFile(hostsPath, []byte("127.0.0.1 localhost\n0.0.0.0 ads.example.com\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(nsswitchPath, []byte("hosts: files dns\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config := readDNSConfig(filepath.Join(dir, "resolv.conf"), nsswitchPath, hostsPath)
+	if config.ResolvConf.Error == "" {
+		t.Error("ResolvConf.Error is empty for a missing file")
+	}
+	for _, resolver := range config.Resolvers {
+		if resolver.Source == "resolv.conf" {
+			t.Errorf("unexpected resolver from the missing resolv.conf: %+v", resolver)
+		}
+	}
+	if want := []string{"files", "dns"}; !reflect.DeepEqual(config.NSSwitchHosts, want) {
+		t.Errorf("NSSwitchHosts = %v, want %v", config.NSSwitchHosts, want)
+	}
+	if len(config.HostsEntries) != 1 || config.HostsBlockedCount != 1 {
+		t.Errorf("HostsEntries = %v, HostsBlockedCount = %d; want 1 entry and 1 blocked", config.HostsEntries, config.HostsBlockedCount)
+	}
+}
@@ -0,0 +1,12 @@
+//go:build !linux
+
+package network
+
+import (
+	anynetwork "privacy-buddy/backend/network"
+)
+
+// NewDNSConfigService returns a service that reports the DNS configuration as not supported.
+func NewDNSConfigService() anynetwork.DNSConfigService {
+	return anynetwork.UnsupportedDNSConfigService{}
+}
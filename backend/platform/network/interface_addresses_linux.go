@@ -0,0 +1,143 @@
+//go:build linux
+
+package network
+
+import (
+	"encoding/binary"
+	"net"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+
+	anynetwork "privacy-buddy/backend/network"
+)
+
+// infinityLifeTime marks addresses that do not expire (INFINITY_LIFE_TIME in linux/if_addr.h).
+const infinityLifeTime = 0xFFFFFFFF
+
+// addressScopes maps rtm_scope values of addresses to the scope names of InterfaceAddress.
+var addressScopes = map[uint8]string{
+	unix.RT_SCOPE_UNIVERSE: "global",
+	unix.RT_SCOPE_SITE:     "site",
+	unix.RT_SCOPE_LINK:     "link",
+	unix.RT_SCOPE_HOST:     "host",
+	unix.RT_SCOPE_NOWHERE:  "nowhere",
+}
+
+// addressFlags lists the address flags in the order "ip address" prints them.
+// IFA_F_SECONDARY and IFA_F_TEMPORARY share a bit; see addressFlagNames.
+var addressFlags = []struct {
+	flag uint32
+	name string
+}{
+	{unix.IFA_F_NODAD, "nodad"},
+	{unix.IFA_F_OPTIMISTIC, "optimistic"},
+	{unix.IFA_F_DADFAILED, "dadfailed"},
+	{unix.IFA_F_HOMEADDRESS, "home"},
+	{unix.IFA_F_DEPRECATED, "deprecated"},
+	{unix.IFA_F_TENTATIVE, "tentative"},
+	{unix.IFA_F_MANAGETEMPADDR, "mngtmpaddr"},
+	{unix.IFA_F_NOPREFIXROUTE, "noprefixroute"},
+	{unix.IFA_F_MCAUTOJOIN, "autojoin"},
+	{unix.IFA_F_STABLE_PRIVACY, "stable-privacy"},
+}
+
+// readInterfaceAddresses dumps the IPv4 and IPv6 addresses of all interfaces, keyed by interface index.
+func readInterfaceAddresses() (map[int][]anynetwork.InterfaceAddress, error) {
+	messages, err := netlinkDump(unix.NETLINK_ROUTE, unix.RTM_GETADDR, make([]byte, unix.SizeofIfAddrmsg))
+	if err != nil {
+		return nil, err
+	}
+	addresses := make(map[int][]anynetwork.InterfaceAddress)
+	for _, m := range messages {
+		if index, address, ok := parseInterfaceAddressMessage(m); ok {
+			addresses[index] = append(addresses[index], address)
+		}
+	}
+	return addresses, nil
+}
+
+// parseInterfaceAddressMessage parses an RTM_NEWADDR or RTM_DELADDR message and returns the
+// interface index and the address. For point-to-point links IFA_LOCAL is the own address and
+// IFA_ADDRESS the peer.
+func parseInterfaceAddressMessage(m syscall.NetlinkMessage) (int, anynetwork.InterfaceAddress, bool) {
+	if (m.Header.Type != unix.RTM_NEWADDR && m.Header.Type != unix.RTM_DELADDR) || len(m.Data) < unix.SizeofIfAddrmsg {
+		return 0, anynetwork.InterfaceAddress{}, false
+	}
+	family, prefixLen, flags, scope := m.Data[0], int(m.Data[1]), uint32(m.Data[2]), m.Data[3]
+	index := int(binary.NativeEndian.Uint32(m.Data[4:8]))
+	attrs := netlinkAttributeMap(m.Data[unix.SizeofIfAddrmsg:])
+
+	addr := attrs[unix.IFA_LOCAL]
+	if len(addr) == 0 {
+		addr = attrs[unix.IFA_ADDRESS]
+	}
+	ip := ipString(addr)
+	headerUnset := family == unix.AF_UNSPEC
+	if headerUnset {
+		// Some network stacks leave the header unset; the prefix length is then unknown (0)
+		// and the scope is derived from the address.
+		switch len(addr) {
+		case net.IPv4len:
+			family = unix.AF_INET
+		case net.IPv6len:
+			family = unix.AF_INET6
+		}
+	}
+	if ip == "" || (family != unix.AF_INET && family != unix.AF_INET6) {
+		return 0, anynetwork.InterfaceAddress{}, false
+	}
+	// IFA_FLAGS (Linux 3.14+) carries the flags that do not fit into ifa_flags
+	if value, ok := attrs[unix.IFA_FLAGS]; ok && len(value) >= 4 {
+		flags = attributeUint32(value)
+	}
+
+	address := anynetwork.InterfaceAddress{
+		IP:           ip,
+		PrefixLength: prefixLen,
+		Family:       familyName(uint32(family)),
+		Scope:        addressScopes[scope],
+		Flags:        addressFlagNames(flags, family),
+		Broadcast:    ipString(attrs[unix.IFA_BROADCAST]),
+		Label:        strings.TrimRight(string(attrs[unix.IFA_LABEL]), "\x00"),
+	}
+	if address.Scope == "" || headerUnset {
+		address.Scope = anynetwork.AddressScope(net.ParseIP(ip))
+	}
+	// Without IFA_CACHEINFO the lifetimes stay 0, i.e., unknown
+	if cacheInfo := attrs[unix.IFA_CACHEINFO]; len(cacheInfo) >= 8 {
+		address.PreferredLifetime = addressLifetime(binary.NativeEndian.Uint32(cacheInfo[0:4]))
+		address.ValidLifetime = addressLifetime(binary.NativeEndian.Uint32(cacheInfo[4:8]))
+	}
+	return index, address, true
+}
+
+// addressFlagNames converts IFA_F_* flags into names. The shared bit of IFA_F_SECONDARY is
+// "temporary" for IPv6 privacy addresses; addresses without IFA_F_PERMANENT are "dynamic".
+func addressFlagNames(flags uint32, family uint8) []string {
+	names := []string{}
+	if flags&unix.IFA_F_SECONDARY != 0 {
+		if family == unix.AF_INET6 {
+			names = append(names, "temporary")
+		} else {
+			names = append(names, "secondary")
+		}
+	}
+	if flags&unix.IFA_F_PERMANENT == 0 {
+		names = append(names, "dynamic")
+	}
+	for _, f := range addressFlags {
+		if flags&f.flag != 0 {
+			names = append(names, f.name)
+		}
+	}
+	return names
+}
+
+func addressLifetime(seconds uint32) int64 {
+	if seconds == infinityLifeTime {
+		return -1
+	}
+	return int64(seconds)
+}
@@ -0,0 +1,85 @@
+//go:build linux
+
+package network
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	anynetwork "privacy-buddy/backend/network"
+)
+
+const sysClassNet = "/sys/class/net"
+
+// LinuxInterfaceCounterService reads interface counters from /sys/class/net/*/statistics.
+type LinuxInterfaceCounterService struct {
+	fallback     anynetwork.InterfaceCounterService
+	fallbackOnce sync.Once
+}
+
+// GetInterfaceCounters returns the counters of all interfaces, falling back to gopsutil
+// (/proc/net/dev) if sysfs is not mounted, e.g., in some containers.
+func (s *LinuxInterfaceCounterService) GetInterfaceCounters() (map[string]anynetwork.InterfaceCounters, error) {
+	counters, err := readSysfsInterfaceCounters()
+	if err == nil {
+		return counters, nil
+	}
+	s.fallbackOnce.Do(func() {
+		log.Printf("WARN: Could not read interface counters from sysfs, using gopsutil: %v", err)
+	})
+	return s.fallback.GetInterfaceCounters()
+}
+
+// NewInterfaceCounterService creates the Linux implementation of InterfaceCounterService.
+func NewInterfaceCounterService() anynetwork.InterfaceCounterService {
+	return &LinuxInterfaceCounterService{fallback: anynetwork.GopsutilInterfaceCounterService{}}
+}
+
+func readSysfsInterfaceCounters() (map[string]anynetwork.InterfaceCounters, error) {
+	entries, err := os.ReadDir(sysClassNet)
+	if err != nil {
+		return nil, err
+	}
+
+	counters := make(map[string]anynetwork.InterfaceCounters, len(entries))
+	for _, entry := range entries {
+		dir := filepath.Join(sysClassNet, entry.Name(), "statistics")
+		if _, err := os.Stat(dir); err != nil {
+			continue // Interface removed in the meantime
+		}
+		read := func(name string) uint64 {
+			return readSysfsUint(filepath.Join(dir, name))
+		}
+		counters[entry.Name()] = anynetwork.InterfaceCounters{
+			RxBytes:   read("rx_bytes"),
+			TxBytes:   read("tx_bytes"),
+			RxPackets: read("rx_packets"),
+			TxPackets: read("tx_packets"),
+			RxErrors:  read("rx_errors"),
+			TxErrors:  read("tx_errors"),
+			RxDropped: read("rx_dropped"),
+			TxDropped: read("tx_dropped"),
+			Multicast: read("multicast"),
+		}
+	}
+	if len(counters) == 0 {
+		return nil, fmt.Errorf("no interface statistics found in %s", sysClassNet)
+	}
+	return counters, nil
+}
+
+// readSysfsUint reads a sysfs attribute holding a single unsigned number; missing or
+// unreadable attributes count as 0.
+func readSysfsUint(path string) uint64 {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	value, _ := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	return value
+}
@@ -0,0 +1,12 @@
+//go:build !linux
+
+package network
+
+import (
+	anynetwork "privacy-buddy/backend/network"
+)
+
+// NewInterfaceCounterService returns the gopsutil based interface counter implementation.
+func NewInterfaceCounterService() anynetwork.InterfaceCounterService {
+	return anynetwork.GopsutilInterfaceCounterService{}
+}
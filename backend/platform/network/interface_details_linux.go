@@ -0,0 +1,220 @@
+//go:build linux
+
+package network
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	anynetwork "privacy-buddy/backend/network"
+)
+
+// Flags of /sys/class/net/*/tun_flags (linux/if_tun.h).
+const (
+	iffTun = 0x0001
+	iffTap = 0x0002
+)
+
+// linkKinds maps IFLA_INFO_KIND values to the interface kinds used in NetworkInterface.
+// Kinds not listed here are passed through, e.g., "macvlan" or "vxlan".
+var linkKinds = map[string]string{
+	"bridge":    "bridge",
+	"bond":      "bond",
+	"team":      "bond",
+	"vlan":      "vlan",
+	"veth":      "veth",
+	"wireguard": "wireguard",
+	"dummy":     "dummy",
+}
+
+// interfaceOrigins guesses the software that created an interface from its name.
+var interfaceOrigins = []struct {
+	pattern *regexp.Regexp
+	origin  string
+}{
+	{regexp.MustCompile(`^(docker\d+|br-[0-9a-f]{12})$`), "docker"},
+	{regexp.MustCompile(`^(cni-podman\d+|podman\d+)$`), "podman"},
+	{regexp.MustCompile(`^(virbr\d+(-nic)?|vnet\d+)$`), "libvirt"},
+	{regexp.MustCompile(`^(lxcbr\d+|lxdbr\d+)$`), "lxc"},
+	{regexp.MustCompile(`^(cni\d+|flannel\.\d+|cali[0-9a-f]+|weave|kube-bridge)$`), "kubernetes"},
+	{regexp.MustCompile(`^vboxnet\d+$`), "virtualbox"},
+	{regexp.MustCompile(`^vmnet\d+$`), "vmware"},
+	{regexp.MustCompile(`^tailscale\d+$`), "tailscale"},
+	{regexp.MustCompile(`^zt[0-9a-z]+$`), "zerotier"},
+}
+
+// linkInfo is the part of an RTM_NEWLINK message used for classification.
+type linkInfo struct {
+	kind      string
+	link      int  // IFLA_LINK: lower device or veth peer
+	linkNetns bool // IFLA_LINK refers to another network namespace
+	vlanID    int
+}
+
+// classifyInterfaces fills kind, driver, link parameters and master/parent relations of
+// the interfaces from sysfs and an RTM_GETLINK dump. Missing information is left empty.
+func classifyInterfaces(interfaces []anynetwork.NetworkInterface) {
+	links := readLinkInfo()
+	names := make(map[int]string, len(interfaces))
+	for _, iface := range interfaces {
+		names[iface.Index] = iface.Name
+	}
+
+	slaves := make(map[string][]string)
+	for i := range interfaces {
+		iface := &interfaces[i]
+		dir := filepath.Join(sysClassNet, iface.Name)
+		info := links[iface.Index]
+
+		_, err := os.Stat(filepath.Join(dir, "device"))
+		iface.IsVirtual = err != nil
+		iface.Kind = interfaceKind(iface, dir, info.kind)
+		iface.Driver = interfaceDriver(dir, info.kind)
+		iface.OperState = readSysfsString(filepath.Join(dir, "operstate"))
+		if speed, err := strconv.Atoi(readSysfsString(filepath.Join(dir, "speed"))); err == nil && speed > 0 {
+			iface.SpeedMbps = speed
+		}
+		if duplex := readSysfsString(filepath.Join(dir, "duplex")); duplex == "full" || duplex == "half" {
+			iface.Duplex = duplex
+		}
+		if master, err := os.Readlink(filepath.Join(dir, "master")); err == nil {
+			iface.Master = filepath.Base(master)
+			slaves[iface.Master] = append(slaves[iface.Master], iface.Name)
+		}
+		if info.link != 0 && info.link != iface.Index && !info.linkNetns {
+			iface.Parent = names[info.link]
+		}
+		iface.VLANID = info.vlanID
+	}
+
+	for i := range interfaces {
+		iface := &interfaces[i]
+		iface.Slaves = slaves[iface.Name]
+		sort.Strings(iface.Slaves)
+		iface.Origin = interfaceOrigin(iface.Name)
+	}
+	// Ports inherit the origin of their bridge, e.g., veth pairs of Docker containers.
+	origins := make(map[string]string, len(interfaces))
+	for _, iface := range interfaces {
+		origins[iface.Name] = iface.Origin
+	}
+	for i := range interfaces {
+		if interfaces[i].Origin == "" && interfaces[i].Master != "" {
+			interfaces[i].Origin = origins[interfaces[i].Master]
+		}
+	}
+}
+
+// interfaceKind classifies an interface. The netlink link kind is preferred; interfaces
+// without one (physical devices, older kernels) are classified from sysfs.
+func interfaceKind(iface *anynetwork.NetworkInterface, dir string, kind string) string {
+	if iface.IsLoopback {
+		return "loopback"
+	}
+	if _, err := os.Stat(filepath.Join(dir, "wireless")); err == nil {
+		return "wireless"
+	}
+	if _, err := os.Stat(filepath.Join(dir, "phy80211")); err == nil {
+		return "wireless"
+	}
+	if flags, err := strconv.ParseUint(readSysfsString(filepath.Join(dir, "tun_flags")), 0, 32); err == nil {
+		if flags&iffTap != 0 {
+			return "tap"
+		}
+		if flags&iffTun != 0 {
+			return "tun"
+		}
+	}
+	if kind != "" {
+		if mapped, ok := linkKinds[kind]; ok {
+			return mapped
+		}
+		return kind
+	}
+
+	for _, line := range strings.Split(readSysfsString(filepath.Join(dir, "uevent")), "\n") {
+		if devType, ok := strings.CutPrefix(line, "DEVTYPE="); ok {
+			if mapped, ok := linkKinds[devType]; ok {
+				return mapped
+			}
+			if devType == "wlan" {
+				return "wireless"
+			}
+		}
+	}
+	if !iface.IsVirtual && readSysfsString(filepath.Join(dir, "type")) == strconv.Itoa(unix.ARPHRD_ETHER) {
+		return "ethernet"
+	}
+	return "other"
+}
+
+// interfaceDriver returns the kernel driver of a hardware device or the link kind of a virtual one.
+func interfaceDriver(dir string, kind string) string {
+	if driver, err := os.Readlink(filepath.Join(dir, "device", "driver")); err == nil {
+		return filepath.Base(driver)
+	}
+	return kind
+}
+
+func interfaceOrigin(name string) string {
+	for _, candidate := range interfaceOrigins {
+		if candidate.pattern.MatchString(name) {
+			return candidate.origin
+		}
+	}
+	return ""
+}
+
+// readLinkInfo dumps all links with RTM_GETLINK and returns kind, lower device and VLAN ID
+// per interface index. It returns an empty map if netlink is unavailable.
+func readLinkInfo() map[int]linkInfo {
+	links := make(map[int]linkInfo)
+	request := make([]byte, unix.SizeofIfInfomsg)
+	request[0] = unix.AF_UNSPEC
+
+	messages, err := netlinkDump(unix.NETLINK_ROUTE, unix.RTM_GETLINK, request)
+	if err != nil {
+		return links
+	}
+	for _, m := range messages {
+		if m.Header.Type != unix.RTM_NEWLINK || len(m.Data) < unix.SizeofIfInfomsg {
+			continue
+		}
+		index := int(int32(binary.NativeEndian.Uint32(m.Data[4:8])))
+		attrs := netlinkAttributeMap(m.Data[unix.SizeofIfInfomsg:])
+
+		var info linkInfo
+		if link := attrs[unix.IFLA_LINK]; len(link) >= 4 {
+			info.link = int(int32(binary.NativeEndian.Uint32(link)))
+		}
+		_, info.linkNetns = attrs[unix.IFLA_LINK_NETNSID]
+		if linkInfoAttr, ok := attrs[unix.IFLA_LINKINFO]; ok {
+			nested := netlinkAttributeMap(linkInfoAttr)
+			info.kind = strings.TrimRight(string(nested[unix.IFLA_INFO_KIND]), "\x00")
+			if info.kind == "vlan" {
+				data := netlinkAttributeMap(nested[unix.IFLA_INFO_DATA])
+				if id := data[unix.IFLA_VLAN_ID]; len(id) >= 2 {
+					info.vlanID = int(binary.NativeEndian.Uint16(id))
+				}
+			}
+		}
+		links[index] = info
+	}
+	return links
+}
+
+// readSysfsString reads a sysfs attribute; unreadable attributes yield an empty string.
+func readSysfsString(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
@@ -0,0 +1,170 @@
+//go:build linux || windows || darwin
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/google/gopacket/pcap"
+
+	anynetwork "privacy-buddy/backend/network"
+)
+
+// npcapDevicePrefix starts the names of Npcap devices, followed by the adapter GUID or "Loopback".
+const npcapDevicePrefix = `\Device\NPF_`
+
+// Flags of pcap_if_t (pcap/pcap.h).
+const (
+	pcapIfLoopback = 0x00000001
+	pcapIfUp       = 0x00000002
+	pcapIfRunning  = 0x00000004
+)
+
+// mergeCaptureDevices adds the capture state to the interfaces and appends the devices that
+// only libpcap knows, e.g., "any", "nflog" or "bluetooth-monitor". Devices are matched by
+// name, by the adapter GUID that Npcap uses as name on Windows and, if no GUID is known,
+// by address.
+// devicesErr is the error of pcap.FindAllDevs; permissionProblem describes missing
+// capture privileges and is empty if capturing is allowed.
+func mergeCaptureDevices(interfaces []anynetwork.NetworkInterface, devices []pcap.Interface, devicesErr error, permissionProblem string) []anynetwork.NetworkInterface {
+	matched := make(map[string]bool, len(devices))
+	for i := range interfaces {
+		iface := &interfaces[i]
+		iface.Sources = append([]string{"net"}, iface.Sources...)
+		iface.HasAddresses = len(iface.Addrs) > 0
+		if iface.DisplayName == "" {
+			iface.DisplayName = iface.Name
+		}
+
+		device := findCaptureDevice(devices, *iface)
+		if device != nil {
+			matched[device.Name] = true
+			iface.Sources = append(iface.Sources, "pcap")
+			iface.CaptureName = device.Name
+			if device.Description != "" {
+				iface.Description = device.Description
+				iface.DisplayName = device.Description
+			}
+		}
+		iface.CaptureUnavailableReason = captureUnavailableReason(device != nil, iface.IsUp, devicesErr, permissionProblem)
+		iface.CanCapture = iface.CaptureUnavailableReason == ""
+	}
+
+	for _, device := range devices {
+		if matched[device.Name] {
+			continue
+		}
+		var addrs []string
+		for _, address := range device.Addresses {
+			if address.IP == nil {
+				continue
+			}
+			if ones, _ := net.IPMask(address.Netmask).Size(); address.Netmask != nil {
+				addrs = append(addrs, fmt.Sprintf("%s/%d", address.IP, ones))
+			} else {
+				addrs = append(addrs, address.IP.String())
+			}
+		}
+		displayName := device.Description
+		if displayName == "" {
+			displayName = device.Name
+		}
+		isUp := device.Flags&pcapIfUp != 0
+		// Npcap devices are adapters, e.g., hidden ones or the Npcap loopback adapter
+		kind, isVirtual := "pseudo", true
+		if strings.HasPrefix(device.Name, npcapDevicePrefix) {
+			kind, isVirtual = "", false
+			if device.Flags&pcapIfLoopback != 0 {
+				kind = "loopback"
+			}
+		}
+		iface := anynetwork.NetworkInterface{
+			Name:         device.Name,
+			DisplayName:  displayName,
+			Description:  device.Description,
+			Flags:        pcapFlagNames(device.Flags),
+			Addrs:        addrs,
+			IsUp:         isUp,
+			IsLoopback:   device.Flags&pcapIfLoopback != 0,
+			Kind:         kind,
+			IsVirtual:    isVirtual,
+			Sources:      []string{"pcap"},
+			HasAddresses: len(addrs) > 0,
+			CaptureName:  device.Name,
+		}
+		// Pseudo devices like "nflog" lack the up flag but can be captured on anyway
+		iface.CaptureUnavailableReason = captureUnavailableReason(true, true, nil, permissionProblem)
+		iface.CanCapture = iface.CaptureUnavailableReason == ""
+		interfaces = append(interfaces, iface)
+	}
+	return interfaces
+}
+
+// findCaptureDevice returns the pcap device of an interface or nil. Interfaces with an adapter
+// GUID are matched by it only, so that adapters without addresses are not matched twice.
+func findCaptureDevice(devices []pcap.Interface, iface anynetwork.NetworkInterface) *pcap.Interface {
+	for i := range devices {
+		if devices[i].Name == iface.Name {
+			return &devices[i]
+		}
+	}
+	if iface.IsLoopback {
+		for i := range devices {
+			if strings.EqualFold(devices[i].Name, npcapDevicePrefix+"Loopback") {
+				return &devices[i]
+			}
+		}
+	}
+	if iface.AdapterID != "" {
+		for i := range devices {
+			if strings.EqualFold(devices[i].Name, npcapDevicePrefix+iface.AdapterID) {
+				return &devices[i]
+			}
+		}
+		return nil
+	}
+	ips := make(map[string]bool, len(iface.Addrs))
+	for _, addr := range iface.Addrs {
+		ips[strings.SplitN(addr, "/", 2)[0]] = true
+	}
+	for i := range devices {
+		for _, address := range devices[i].Addresses {
+			if address.IP != nil && ips[address.IP.String()] {
+				return &devices[i]
+			}
+		}
+	}
+	return nil
+}
+
+// captureUnavailableReason returns why an interface cannot be captured on, or an empty string.
+func captureUnavailableReason(listed bool, isUp bool, devicesErr error, permissionProblem string) string {
+	switch {
+	case devicesErr != nil:
+		return fmt.Sprintf("capture library unavailable: %v", devicesErr)
+	case !listed:
+		return "not listed by the capture library"
+	case permissionProblem != "":
+		return permissionProblem
+	case !isUp:
+		return "interface is down"
+	}
+	return ""
+}
+
+// pcapFlagNames converts pcap_if_t flags into the flag names used for net interfaces.
+func pcapFlagNames(flags uint32) []string {
+	names := []string{}
+	if flags&pcapIfUp != 0 {
+		names = append(names, "up")
+	}
+	if flags&pcapIfLoopback != 0 {
+		names = append(names, "loopback")
+	}
+	if flags&pcapIfRunning != 0 {
+		names = append(names, "running")
+	}
+	return names
+}
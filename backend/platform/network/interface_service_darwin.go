@@ -6,8 +6,8 @@ import (
 	"fmt"
 	"net"
 
-	"github.com/shirou/gopsutil/v3/net"
 	"github.com/google/gopacket/pcap"
+	"golang.org/x/sys/unix"
 
 	anynetwork "privacy-buddy/backend/network"
 )
@@ -22,10 +22,8 @@ func (s *DarwinNetworkInterfaceService) ListInterfaces() ([]anynetwork.NetworkIn
 		return nil, fmt.Errorf("failed to get network interfaces: %w", err)
 	}
 
-	pcapDevices, err := pcap.FindAllDevs()
-	if err != nil {
-		return nil, fmt.Errorf("failed to find pcap devices: %w", err)
-	}
+	// Without libpcap the interfaces are still listed, only marked as not capturable
+	pcapDevices, pcapErr := pcap.FindAllDevs()
 
 	var netInterfaces []anynetwork.NetworkInterface
 	for _, iface := range interfaces {
@@ -37,7 +35,7 @@ func (s *DarwinNetworkInterfaceService) ListInterfaces() ([]anynetwork.NetworkIn
 
 		var ipAddrs []string
 		for _, addr := range addrs {
-			ipAddrs = append(ipAddrs, addr.Addr)
+			ipAddrs = append(ipAddrs, addr.String())
 		}
 
 		flags := []string{}
@@ -57,18 +55,8 @@ func (s *DarwinNetworkInterfaceService) ListInterfaces() ([]anynetwork.NetworkIn
 			flags = append(flags, "multicast")
 		}
 
-		description := ""
-		for _, dev := range pcapDevices {
-			if dev.Name == iface.Name {
-				description = dev.Description
-				break
-			}
-		}
-
 		netInterfaces = append(netInterfaces, anynetwork.NetworkInterface{
 			Name:        iface.Name,
-			DisplayName: description, // Use pcap description as DisplayName
-			Description: description,
 			HardwareAddr: iface.HardwareAddr,
 			MTU:         iface.MTU,
 			Flags:       flags,
@@ -80,7 +68,19 @@ func (s *DarwinNetworkInterfaceService) ListInterfaces() ([]anynetwork.NetworkIn
 			IsMulticast: iface.Flags&net.FlagMulticast != 0,
 		})
 	}
-	return netInterfaces, nil
+	return mergeCaptureDevices(netInterfaces, pcapDevices, pcapErr, capturePermissionProblem()), nil
 }
 
+// capturePermissionProblem checks whether the BPF devices can be opened, which by default
+// requires root or membership in the access_bpf group of Wireshark's ChmodBPF.
+func capturePermissionProblem() string {
+	if err := unix.Access("/dev/bpf0", unix.R_OK); err != nil {
+		return fmt.Sprintf("cannot open /dev/bpf*: %v; run as root or install ChmodBPF", err)
+	}
+	return ""
+}
 
+// NewNetworkInterfaceService creates the Darwin implementation of NetworkInterfaceService.
+func NewNetworkInterfaceService() anynetwork.NetworkInterfaceService {
+	return &DarwinNetworkInterfaceService{}
+}
@@ -5,8 +5,13 @@ package network
 import (
 	"fmt"
 	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/google/gopacket/pcap"
+	"golang.org/x/sys/unix"
 
 	anynetwork "privacy-buddy/backend/network"
 )
@@ -14,16 +19,20 @@ import (
 // LinuxNetworkInterfaceService provides Linux-specific implementation for network interface listing.
 type LinuxNetworkInterfaceService struct{}
 
-// ListInterfaces lists all network interfaces on Linux.
+// ListInterfaces lists all network interfaces on Linux, classified by kind with their
+// link parameters and bridge/bond/VLAN relations.
 func (s *LinuxNetworkInterfaceService) ListInterfaces() ([]anynetwork.NetworkInterface, error) {
 	interfaces, err := net.Interfaces()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get network interfaces: %w", err)
 	}
 
-	pcapDevices, err := pcap.FindAllDevs()
+	// Without libpcap the interfaces are still listed, only marked as not capturable
+	pcapDevices, pcapErr := pcap.FindAllDevs()
+
+	addresses, err := readInterfaceAddresses()
 	if err != nil {
-		return nil, fmt.Errorf("failed to find pcap devices: %w", err)
+		fmt.Printf("warning: failed to get interface addresses from netlink: %v\n", err)
 	}
 
 	var netInterfaces []anynetwork.NetworkInterface
@@ -57,18 +66,13 @@ func (s *LinuxNetworkInterfaceService) ListInterfaces() ([]anynetwork.NetworkInt
 			flags = append(flags, "multicast")
 		}
 
-		description := ""
-		for _, dev := range pcapDevices {
-			if dev.Name == iface.Name {
-				description = dev.Description
-				break
-			}
+		var sources []string
+		if _, err := os.Stat(filepath.Join(sysClassNet, iface.Name)); err == nil {
+			sources = append(sources, "sysfs")
 		}
 
 		netInterfaces = append(netInterfaces, anynetwork.NetworkInterface{
 			Name:           iface.Name,
-			DisplayName:    description, // Use pcap description as DisplayName
-			Description:    description,
 			HardwareAddr:   iface.HardwareAddr,
 			MTU:            iface.MTU,
 			Flags:          flags,
@@ -78,7 +82,51 @@ func (s *LinuxNetworkInterfaceService) ListInterfaces() ([]anynetwork.NetworkInt
 			IsBroadcast:    iface.Flags&net.FlagBroadcast != 0,
 			IsPointToPoint: iface.Flags&net.FlagPointToPoint != 0,
 			IsMulticast:    iface.Flags&net.FlagMulticast != 0,
+			Index:          iface.Index,
+			Sources:        sources,
+			Addresses:      withPrefixLengths(addresses[iface.Index], ipAddrs),
 		})
 	}
-	return netInterfaces, nil
+	classifyInterfaces(netInterfaces)
+	return mergeCaptureDevices(netInterfaces, pcapDevices, pcapErr, capturePermissionProblem()), nil
+}
+
+// withPrefixLengths fills in prefix lengths that netlink left unset from the CIDR strings of net.Interface.
+func withPrefixLengths(addresses []anynetwork.InterfaceAddress, cidrs []string) []anynetwork.InterfaceAddress {
+	for i := range addresses {
+		if addresses[i].PrefixLength != 0 {
+			continue
+		}
+		for _, cidr := range cidrs {
+			if ip, ipNet, err := net.ParseCIDR(cidr); err == nil && ip.Equal(net.ParseIP(addresses[i].IP)) {
+				addresses[i].PrefixLength, _ = ipNet.Mask.Size()
+			}
+		}
+	}
+	return addresses
+}
+
+// capturePermissionProblem checks the effective capabilities of the process for CAP_NET_RAW,
+// which opening a packet socket requires.
+func capturePermissionProblem() string {
+	data, err := os.ReadFile("/proc/self/status")
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		value, ok := strings.CutPrefix(line, "CapEff:")
+		if !ok {
+			continue
+		}
+		caps, err := strconv.ParseUint(strings.TrimSpace(value), 16, 64)
+		if err == nil && caps&(1<<unix.CAP_NET_RAW) == 0 {
+			return "missing CAP_NET_RAW; run as root or grant the capability with setcap cap_net_raw,cap_net_admin=eip"
+		}
+	}
+	return ""
+}
+
+// NewNetworkInterfaceService creates the Linux implementation of NetworkInterfaceService.
+func NewNetworkInterfaceService() anynetwork.NetworkInterfaceService {
+	return &LinuxNetworkInterfaceService{}
 }
@@ -5,9 +5,11 @@ package network
 import (
 	"fmt"
 	"net"
+	"unsafe"
 
 	"github.com/google/gopacket/pcap"
 	gopsnet "github.com/shirou/gopsutil/v3/net"
+	"golang.org/x/sys/windows"
 
 	anynetwork "privacy-buddy/backend/network"
 )
@@ -22,9 +24,11 @@ func (s *WindowsNetworkInterfaceService) ListInterfaces() ([]anynetwork.NetworkI
 		return nil, fmt.Errorf("failed to get network interfaces: %w", err)
 	}
 
-	pcapDevices, err := pcap.FindAllDevs()
+	// Without libpcap the interfaces are still listed, only marked as not capturable
+	pcapDevices, pcapErr := pcap.FindAllDevs()
+	adapters, err := adapterIDs()
 	if err != nil {
-		return nil, fmt.Errorf("failed to find pcap devices: %w", err)
+		fmt.Printf("Warning: Failed to get adapter GUIDs, matching capture devices by address: %v\n", err)
 	}
 
 	var netInterfaces []anynetwork.NetworkInterface
@@ -56,21 +60,10 @@ func (s *WindowsNetworkInterfaceService) ListInterfaces() ([]anynetwork.NetworkI
 			flags = append(flags, "multicast")
 		}
 
-				displayName := iface.Name
-		description := ""
-
-		for _, dev := range pcapDevices {
-			if dev.Name == iface.Name {
-				description = dev.Description
-				displayName = dev.Description // Use pcap description as DisplayName
-				break
-			}
-		}
-
 		netInterfaces = append(netInterfaces, anynetwork.NetworkInterface{
 			Name:           iface.Name,
-			DisplayName:    displayName,
-			Description:    description,
+			Index:          iface.Index,
+			AdapterID:      adapters[iface.Index],
 						HardwareAddr:   func() net.HardwareAddr{
 				mac, err := net.ParseMAC(iface.HardwareAddr)
 				if err != nil {
@@ -89,7 +82,36 @@ func (s *WindowsNetworkInterfaceService) ListInterfaces() ([]anynetwork.NetworkI
 			IsMulticast:    containsFlag(iface.Flags, "multicast"),
 		})
 	}
-	return netInterfaces, nil
+	// Npcap's installer decides whether administrators only may capture; FindAllDevs fails then.
+	return mergeCaptureDevices(netInterfaces, pcapDevices, pcapErr, ""), nil
+}
+
+// adapterIDs maps interface indexes to the adapter GUIDs, which Npcap uses in its device names.
+func adapterIDs() (map[int]string, error) {
+	size := uint32(15000)
+	for {
+		buf := make([]byte, size)
+		first := (*windows.IpAdapterAddresses)(unsafe.Pointer(&buf[0]))
+		flags := uint32(windows.GAA_FLAG_SKIP_UNICAST | windows.GAA_FLAG_SKIP_ANYCAST | windows.GAA_FLAG_SKIP_MULTICAST | windows.GAA_FLAG_SKIP_DNS_SERVER)
+		err := windows.GetAdaptersAddresses(windows.AF_UNSPEC, flags, 0, first, &size)
+		if err == windows.ERROR_BUFFER_OVERFLOW {
+			continue // size now holds the required buffer size
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		ids := make(map[int]string)
+		for adapter := first; adapter != nil; adapter = adapter.Next {
+			// Same index as net.Interfaces: IPv6-only adapters have no IPv4 index
+			index := adapter.IfIndex
+			if index == 0 {
+				index = adapter.Ipv6IfIndex
+			}
+			ids[int(index)] = windows.BytePtrToString(adapter.AdapterName)
+		}
+		return ids, nil
+	}
 }
 
 func containsFlag(flags []string, flag string) bool {
@@ -99,4 +121,9 @@ func containsFlag(flags []string, flag string) bool {
 		}
 	}
 	return false
-}
\ No newline at end of file
+}
+
+// NewNetworkInterfaceService creates the Windows implementation of NetworkInterfaceService.
+func NewNetworkInterfaceService() anynetwork.NetworkInterfaceService {
+	return &WindowsNetworkInterfaceService{}
+}
@@ -0,0 +1,325 @@
+//go:build linux
+
+package network
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	anynetwork "privacy-buddy/backend/network"
+)
+
+// Constants of linux/unix_diag.h that are not exported by x/sys/unix.
+const (
+	unixDiagShowPeer = 0x04 // UDIAG_SHOW_PEER
+	unixDiagShowUID  = 0x40 // UDIAG_SHOW_UID
+	unixDiagPeer     = 2    // UNIX_DIAG_PEER
+	unixDiagUID      = 7    // UNIX_DIAG_UID
+	unixDiagReqLen   = 24   // sizeof(struct unix_diag_req)
+	unixDiagMsgLen   = 16   // sizeof(struct unix_diag_msg)
+)
+
+// unixAcceptConnFlag marks listening sockets in the Flags column of /proc/net/unix (__SO_ACCEPTCON).
+const unixAcceptConnFlag = 0x10000
+
+// unixSocketStates maps the St column of /proc/net/unix (socket_state) to a name.
+var unixSocketStates = map[uint64]string{
+	1: "UNCONNECTED",
+	2: "CONNECTING",
+	3: "CONNECTED",
+	4: "DISCONNECTING",
+}
+
+// etherTypeNames names the protocols packet sockets are commonly bound to.
+var etherTypeNames = map[uint32]string{
+	0x0000: "none",
+	0x0003: "ETH_P_ALL",
+	0x0800: "ETH_P_IP",
+	0x0806: "ETH_P_ARP",
+	0x8100: "ETH_P_8021Q",
+	0x86DD: "ETH_P_IPV6",
+	0x888E: "ETH_P_PAE",
+	0x88CC: "ETH_P_LLDP",
+}
+
+// ipProtocolNames names the protocols raw IP sockets are commonly opened for.
+var ipProtocolNames = map[uint32]string{
+	1:   "icmp",
+	2:   "igmp",
+	6:   "tcp",
+	17:  "udp",
+	47:  "gre",
+	50:  "esp",
+	58:  "icmpv6",
+	89:  "ospf",
+	112: "vrrp",
+	132: "sctp",
+	255: "raw",
+}
+
+// LinuxLocalSocketService lists Unix domain sockets and AF_PACKET/raw sockets on Linux.
+type LinuxLocalSocketService struct{}
+
+// GetUnixSockets reads /proc/net/unix. Peers and owners are added from the sock_diag
+// netlink interface where available; unnamed sockets are listed as well.
+func (s *LinuxLocalSocketService) GetUnixSockets() ([]anynetwork.UnixSocket, error) {
+	sockets, err := parseProcNetUnix("/proc/net/unix")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Unix domain sockets: %w", err)
+	}
+
+	// Not fatal: kernels without unix_diag still get the list, just without peers and owners.
+	peers, uids, err := unixSocketDiag()
+	if err != nil {
+		log.Printf("WARN: Could not query unix_diag, Unix socket peers and owners are unknown: %v", err)
+	}
+
+	inodes := make(map[uint64]struct{}, len(sockets))
+	for i := range sockets {
+		sockets[i].PeerInode = peers[sockets[i].Inode]
+		sockets[i].UID, sockets[i].UIDKnown = uids[sockets[i].Inode]
+		inodes[sockets[i].Inode] = struct{}{}
+		if sockets[i].PeerInode != 0 {
+			inodes[sockets[i].PeerInode] = struct{}{}
+		}
+	}
+	owners := socketInodeOwners(inodes)
+	names := newProcessCommCache()
+	for i := range sockets {
+		sockets[i].ProcessName = "N/A"
+		if owner, ok := owners[sockets[i].Inode]; ok {
+			sockets[i].PID = owner.pid
+			if name := names(owner.pid); name != "" {
+				sockets[i].ProcessName = name
+			}
+		}
+		if owner, ok := owners[sockets[i].PeerInode]; ok && sockets[i].PeerInode != 0 {
+			sockets[i].PeerPID = owner.pid
+			sockets[i].PeerProcessName = names(owner.pid)
+		}
+	}
+	return sockets, nil
+}
+
+// GetRawSockets reads /proc/net/packet, /proc/net/raw and /proc/net/raw6.
+func (s *LinuxLocalSocketService) GetRawSockets() ([]anynetwork.RawSocket, error) {
+	sockets, err := parseProcNetPacket("/proc/net/packet")
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to get packet sockets: %w", err)
+	}
+
+	for _, table := range procNetTables {
+		if table.sockType != unix.SOCK_RAW {
+			continue
+		}
+		conns, err := parseProcNetFile("/proc/net/"+table.name, table)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get raw sockets: %w", err)
+		}
+		for _, conn := range conns {
+			// The local port column of raw sockets holds the IP protocol number.
+			sockets = append(sockets, anynetwork.RawSocket{
+				Kind:           "raw",
+				FamilyName:     conn.FamilyName,
+				TypeName:       conn.TypeName,
+				Protocol:       protocolName(ipProtocolNames, conn.LocalPort),
+				ProtocolNumber: conn.LocalPort,
+				LocalIP:        conn.LocalIP,
+				RemoteIP:       conn.RemoteIP,
+				Inode:          conn.Inode,
+				UID:            conn.UID,
+			})
+		}
+	}
+
+	inodes := make(map[uint64]struct{}, len(sockets))
+	for _, socket := range sockets {
+		inodes[socket.Inode] = struct{}{}
+	}
+	owners := socketInodeOwners(inodes)
+	names := newProcessCommCache()
+	for i := range sockets {
+		sockets[i].ProcessName = "N/A"
+		if owner, ok := owners[sockets[i].Inode]; ok {
+			sockets[i].PID = owner.pid
+			if name := names(owner.pid); name != "" {
+				sockets[i].ProcessName = name
+			}
+		}
+	}
+	return sockets, nil
+}
+
+// NewLocalSocketService creates the Linux implementation of LocalSocketService.
+func NewLocalSocketService() anynetwork.LocalSocketService {
+	return &LinuxLocalSocketService{}
+}
+
+// parseProcNetUnix parses /proc/net/unix, skipping lines that cannot be parsed.
+func parseProcNetUnix(path string) ([]anynetwork.UnixSocket, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var sockets []anynetwork.UnixSocket
+	scanner := bufio.NewScanner(file)
+	scanner.Scan() // Header
+	for scanner.Scan() {
+		socket, err := parseProcNetUnixLine(scanner.Text())
+		if err != nil {
+			log.Printf("WARN: Skipping entry of %s: %v", path, err)
+			continue
+		}
+		sockets = append(sockets, socket)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return sockets, nil
+}
+
+// parseProcNetUnixLine parses a line like
+// "0000000000000000: 00000002 00000000 00010000 0001 01 12345 /run/dbus/system_bus_socket".
+func parseProcNetUnixLine(line string) (anynetwork.UnixSocket, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 7 {
+		return anynetwork.UnixSocket{}, fmt.Errorf("unexpected line %q", line)
+	}
+	flags, _ := strconv.ParseUint(fields[3], 16, 32)
+	sockType, _ := strconv.ParseUint(fields[4], 16, 32)
+	state, _ := strconv.ParseUint(fields[5], 16, 8)
+	inode, err := strconv.ParseUint(fields[6], 10, 64)
+	if err != nil {
+		return anynetwork.UnixSocket{}, fmt.Errorf("invalid inode %q", fields[6])
+	}
+
+	socket := anynetwork.UnixSocket{
+		TypeName: socketTypeName(uint32(sockType)),
+		State:    unixSocketStates[state],
+		Inode:    inode,
+	}
+	if flags&unixAcceptConnFlag != 0 {
+		socket.State = "LISTEN"
+	}
+	if len(fields) > 7 {
+		socket.Path = strings.Join(fields[7:], " ")
+		socket.Abstract = strings.HasPrefix(socket.Path, "@")
+	}
+	return socket, nil
+}
+
+// parseProcNetPacket parses /proc/net/packet, skipping lines that cannot be parsed.
+func parseProcNetPacket(path string) ([]anynetwork.RawSocket, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var sockets []anynetwork.RawSocket
+	scanner := bufio.NewScanner(file)
+	scanner.Scan() // Header
+	for scanner.Scan() {
+		socket, err := parseProcNetPacketLine(scanner.Text())
+		if err != nil {
+			log.Printf("WARN: Skipping entry of %s: %v", path, err)
+			continue
+		}
+		sockets = append(sockets, socket)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return sockets, nil
+}
+
+// parseProcNetPacketLine parses a line like
+// "ffff8a0b4c2d1800 3      3    0003   2     1 0      0      23456".
+func parseProcNetPacketLine(line string) (anynetwork.RawSocket, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 9 {
+		return anynetwork.RawSocket{}, fmt.Errorf("unexpected line %q", line)
+	}
+	sockType, _ := strconv.ParseUint(fields[2], 10, 32)
+	protocol, _ := strconv.ParseUint(fields[3], 16, 16)
+	ifIndex, _ := strconv.Atoi(fields[4])
+	uid, _ := strconv.ParseUint(fields[7], 10, 32)
+	inode, err := strconv.ParseUint(fields[8], 10, 64)
+	if err != nil {
+		return anynetwork.RawSocket{}, fmt.Errorf("invalid inode %q", fields[8])
+	}
+
+	socket := anynetwork.RawSocket{
+		Kind:               "packet",
+		FamilyName:         "packet",
+		TypeName:           socketTypeName(uint32(sockType)),
+		Protocol:           protocolName(etherTypeNames, uint32(protocol)),
+		ProtocolNumber:     uint32(protocol),
+		Inode:              inode,
+		UID:                uint32(uid),
+		CapturesAllTraffic: protocol == unix.ETH_P_ALL,
+	}
+	if ifIndex > 0 {
+		socket.Interface = strconv.Itoa(ifIndex)
+		if iface, err := net.InterfaceByIndex(ifIndex); err == nil {
+			socket.Interface = iface.Name
+		}
+	}
+	return socket, nil
+}
+
+// unixSocketDiag dumps all Unix domain sockets via NETLINK_SOCK_DIAG and returns the
+// peer inode and owner UID of each socket. /proc/net/unix reports neither.
+func unixSocketDiag() (map[uint64]uint64, map[uint64]uint32, error) {
+	request := make([]byte, unixDiagReqLen)
+	request[0] = unix.AF_UNIX
+	binary.NativeEndian.PutUint32(request[4:8], 0xFFFFFFFF) // All states
+	binary.NativeEndian.PutUint32(request[12:16], unixDiagShowPeer|unixDiagShowUID)
+
+	messages, err := netlinkDump(unix.NETLINK_SOCK_DIAG, unix.SOCK_DIAG_BY_FAMILY, request)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	peers := make(map[uint64]uint64, len(messages))
+	uids := make(map[uint64]uint32, len(messages))
+	for _, m := range messages {
+		if len(m.Data) < unixDiagMsgLen {
+			continue
+		}
+		inode := uint64(binary.NativeEndian.Uint32(m.Data[4:8]))
+		for _, attr := range parseNetlinkAttributes(m.Data[unixDiagMsgLen:]) {
+			if len(attr.Value) < 4 {
+				continue
+			}
+			switch attr.Type {
+			case unixDiagPeer:
+				peers[inode] = uint64(binary.NativeEndian.Uint32(attr.Value))
+			case unixDiagUID:
+				uids[inode] = binary.NativeEndian.Uint32(attr.Value)
+			}
+		}
+	}
+	return peers, uids, nil
+}
+
+// protocolName returns the name of a protocol number or its hexadecimal value if unknown.
+func protocolName(names map[uint32]string, number uint32) string {
+	if name, ok := names[number]; ok {
+		return name
+	}
+	return fmt.Sprintf("0x%04x", number)
+}
@@ -0,0 +1,106 @@
+//go:build linux
+
+package network
+
+import (
+	"reflect"
+	"testing"
+
+	anynetwork "privacy-buddy/backend/network"
+)
+
+func TestParseProcNetUnixLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		want    anynetwork.UnixSocket
+		wantErr bool
+	}{
+		{
+			name: "listening socket",
+			line: "0000000000000000: 00000002 00000000 00010000 0001 01 12345 /run/dbus/system_bus_socket",
+			want: anynetwork.UnixSocket{Path: "/run/dbus/system_bus_socket", TypeName: "SOCK_STREAM", State: "LISTEN", Inode: 12345},
+		},
+		{
+			name: "connected abstract socket",
+			line: "0000000000000000: 00000003 00000000 00000000 0001 03 23456 @/tmp/.X11-unix/X0",
+			want: anynetwork.UnixSocket{Path: "@/tmp/.X11-unix/X0", Abstract: true, TypeName: "SOCK_STREAM", State: "CONNECTED", Inode: 23456},
+		},
+		{
+			name: "unnamed datagram socket",
+			line: "0000000000000000: 00000002 00000000 00000000 0002 01 34567",
+			want: anynetwork.UnixSocket{TypeName: "SOCK_DGRAM", State: "UNCONNECTED", Inode: 34567},
+		},
+		{
+			name: "path with spaces",
+			line: "0000000000000000: 00000002 00000000 00010000 0005 01 45678 /run/user/1000/my socket",
+			want: anynetwork.UnixSocket{Path: "/run/user/1000/my socket", TypeName: "SOCK_SEQPACKET", State: "LISTEN", Inode: 45678},
+		},
+		{name: "too few fields", line: "0000000000000000: 00000002 00000000 00010000 0001 01", wantErr: true},
+		{name: "invalid inode", line: "0000000000000000: 00000002 00000000 00010000 0001 01 x12 /run/x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseProcNetUnixLine(tt.line)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseProcNetUnixLine() = %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseProcNetUnixLine() failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseProcNetUnixLine() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseProcNetPacketLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		want    anynetwork.RawSocket
+		wantErr bool
+	}{
+		{
+			name: "sniffer on all interfaces",
+			line: "ffff8a0b4c2d1800 3      3    0003   0     1 0      0      23456",
+			want: anynetwork.RawSocket{
+				Kind: "packet", FamilyName: "packet", TypeName: "SOCK_RAW", Protocol: "ETH_P_ALL",
+				ProtocolNumber: 0x0003, Inode: 23456, CapturesAllTraffic: true,
+			},
+		},
+		{
+			name: "DHCP client",
+			line: "ffff8a0b4c2d2000 3      2    0800   0     1 0      101    34567",
+			want: anynetwork.RawSocket{
+				Kind: "packet", FamilyName: "packet", TypeName: "SOCK_DGRAM", Protocol: "ETH_P_IP",
+				ProtocolNumber: 0x0800, Inode: 34567, UID: 101,
+			},
+		},
+		{name: "too few fields", line: "ffff8a0b4c2d1800 3 3 0003 0 1 0 0", wantErr: true},
+		{name: "invalid inode", line: "ffff8a0b4c2d1800 3 3 0003 0 1 0 0 -1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseProcNetPacketLine(tt.line)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseProcNetPacketLine() = %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseProcNetPacketLine() failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseProcNetPacketLine() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+//go:build !linux
+
+package network
+
+import (
+	anynetwork "privacy-buddy/backend/network"
+)
+
+// NewLocalSocketService returns a service that reports Unix domain and raw socket listing as not supported.
+func NewLocalSocketService() anynetwork.LocalSocketService {
+	return anynetwork.UnsupportedLocalSocketService{}
+}
@@ -0,0 +1,198 @@
+//go:build linux
+
+package network
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+var netlinkSequence = uint32(time.Now().Unix())
+
+// netlinkAttribute is a single rtattr/nlattr of a netlink message.
+type netlinkAttribute struct {
+	Type  uint16
+	Value []byte
+}
+
+// netlinkDump sends a dump request and returns all reply messages.
+func netlinkDump(protocol int, msgType uint16, payload []byte) ([]syscall.NetlinkMessage, error) {
+	return netlinkRoundTrip(protocol, msgType, unix.NLM_F_REQUEST|unix.NLM_F_DUMP, payload)
+}
+
+// netlinkRoundTrip sends a netlink request and collects the replies until NLMSG_DONE,
+// an error/ACK message or the first reply that is not part of a multipart message.
+func netlinkRoundTrip(protocol int, msgType uint16, flags uint16, payload []byte) ([]syscall.NetlinkMessage, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_RAW|unix.SOCK_CLOEXEC, protocol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open netlink socket: %w", err)
+	}
+	defer unix.Close(fd)
+
+	if err := unix.Bind(fd, &unix.SockaddrNetlink{Family: unix.AF_NETLINK}); err != nil {
+		return nil, fmt.Errorf("failed to bind netlink socket: %w", err)
+	}
+
+	seq := atomic.AddUint32(&netlinkSequence, 1)
+	request := make([]byte, unix.NLMSG_HDRLEN, unix.NLMSG_HDRLEN+len(payload))
+	binary.NativeEndian.PutUint32(request[0:4], uint32(unix.NLMSG_HDRLEN+len(payload)))
+	binary.NativeEndian.PutUint16(request[4:6], msgType)
+	binary.NativeEndian.PutUint16(request[6:8], flags)
+	binary.NativeEndian.PutUint32(request[8:12], seq)
+	request = append(request, payload...)
+
+	if err := unix.Sendto(fd, request, 0, &unix.SockaddrNetlink{Family: unix.AF_NETLINK}); err != nil {
+		return nil, fmt.Errorf("failed to send netlink request: %w", err)
+	}
+
+	var messages []syscall.NetlinkMessage
+	buf := make([]byte, os.Getpagesize()*8)
+	for {
+		n, _, err := unix.Recvfrom(fd, buf, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to receive netlink reply: %w", err)
+		}
+		replies, err := syscall.ParseNetlinkMessage(buf[:n])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse netlink reply: %w", err)
+		}
+
+		for _, m := range replies {
+			if m.Header.Seq != seq {
+				continue
+			}
+			switch m.Header.Type {
+			case unix.NLMSG_DONE:
+				return messages, nil
+			case unix.NLMSG_ERROR:
+				if err := netlinkError(m.Data); err != nil {
+					return nil, err
+				}
+				return messages, nil // ACK
+			}
+			messages = append(messages, m)
+			if m.Header.Flags&unix.NLM_F_MULTI == 0 {
+				return messages, nil
+			}
+		}
+	}
+}
+
+// netlinkError converts the payload of an NLMSG_ERROR message into an error; nil means ACK.
+func netlinkError(data []byte) error {
+	if len(data) < 4 {
+		return fmt.Errorf("truncated netlink error message")
+	}
+	errno := int32(binary.NativeEndian.Uint32(data[:4]))
+	if errno == 0 {
+		return nil
+	}
+	return fmt.Errorf("netlink request failed: %w", syscall.Errno(-errno))
+}
+
+// parseNetlinkAttributes parses a sequence of rtattr/nlattr structures.
+func parseNetlinkAttributes(data []byte) []netlinkAttribute {
+	var attrs []netlinkAttribute
+	for len(data) >= unix.SizeofRtAttr {
+		length := int(binary.NativeEndian.Uint16(data[0:2]))
+		attrType := binary.NativeEndian.Uint16(data[2:4]) &^ (unix.NLA_F_NESTED | unix.NLA_F_NET_BYTEORDER)
+		if length < unix.SizeofRtAttr || length > len(data) {
+			break
+		}
+		attrs = append(attrs, netlinkAttribute{Type: attrType, Value: data[unix.SizeofRtAttr:length]})
+		aligned := netlinkAlign(length)
+		if aligned > len(data) {
+			break
+		}
+		data = data[aligned:]
+	}
+	return attrs
+}
+
+// netlinkAttributeMap indexes attributes by type; for repeated types the last one wins.
+func netlinkAttributeMap(data []byte) map[uint16][]byte {
+	attrs := make(map[uint16][]byte)
+	for _, attr := range parseNetlinkAttributes(data) {
+		attrs[attr.Type] = attr.Value
+	}
+	return attrs
+}
+
+func netlinkAlign(length int) int {
+	return (length + unix.NLMSG_ALIGNTO - 1) & ^(unix.NLMSG_ALIGNTO - 1)
+}
+
+// interfaceNamesByIndex returns a lookup function for interface names that caches net.Interfaces.
+func interfaceNamesByIndex() func(index int) string {
+	names := make(map[int]string)
+	return func(index int) string {
+		if name, ok := names[index]; ok {
+			return name
+		}
+		name := ""
+		if iface, err := net.InterfaceByIndex(index); err == nil {
+			name = iface.Name
+		}
+		names[index] = name
+		return name
+	}
+}
+
+// netlinkAttributeBytes encodes a single rtattr/nlattr including its padding.
+func netlinkAttributeBytes(attrType uint16, value []byte) []byte {
+	length := unix.SizeofRtAttr + len(value)
+	attr := make([]byte, netlinkAlign(length))
+	binary.NativeEndian.PutUint16(attr[0:2], uint16(length))
+	binary.NativeEndian.PutUint16(attr[2:4], attrType)
+	copy(attr[unix.SizeofRtAttr:], value)
+	return attr
+}
+
+// genetlinkFamilyID resolves the ID of a generic netlink family, e.g., "nl80211".
+func genetlinkFamilyID(name string) (uint16, error) {
+	messages, err := netlinkRoundTrip(unix.NETLINK_GENERIC, unix.GENL_ID_CTRL, unix.NLM_F_REQUEST,
+		genetlinkPayload(unix.CTRL_CMD_GETFAMILY, netlinkAttributeBytes(unix.CTRL_ATTR_FAMILY_NAME, append([]byte(name), 0))))
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve generic netlink family %s: %w", name, err)
+	}
+	for _, m := range messages {
+		if len(m.Data) < unix.GENL_HDRLEN {
+			continue
+		}
+		if id := netlinkAttributeMap(m.Data[unix.GENL_HDRLEN:])[unix.CTRL_ATTR_FAMILY_ID]; len(id) >= 2 {
+			return binary.NativeEndian.Uint16(id), nil
+		}
+	}
+	return 0, fmt.Errorf("generic netlink family %s not found", name)
+}
+
+// genetlinkDump sends a generic netlink dump request and returns the attributes of every reply.
+func genetlinkDump(familyID uint16, cmd uint8, attrs ...[]byte) ([]map[uint16][]byte, error) {
+	messages, err := netlinkDump(unix.NETLINK_GENERIC, familyID, genetlinkPayload(cmd, attrs...))
+	if err != nil {
+		return nil, err
+	}
+	replies := make([]map[uint16][]byte, 0, len(messages))
+	for _, m := range messages {
+		if m.Header.Type == familyID && len(m.Data) >= unix.GENL_HDRLEN {
+			replies = append(replies, netlinkAttributeMap(m.Data[unix.GENL_HDRLEN:]))
+		}
+	}
+	return replies, nil
+}
+
+// genetlinkPayload builds a genlmsghdr with the given command followed by the attributes.
+func genetlinkPayload(cmd uint8, attrs ...[]byte) []byte {
+	payload := []byte{cmd, 1, 0, 0} // cmd, version, reserved
+	for _, attr := range attrs {
+		payload = append(payload, attr...)
+	}
+	return payload
+}
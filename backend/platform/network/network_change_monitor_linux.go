@@ -0,0 +1,510 @@
+//go:build linux
+
+package network
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"net"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	anynetwork "privacy-buddy/backend/network"
+)
+
+// networkChangeGroups are the rtnetlink multicast groups of the monitor as legacy RTMGRP_* bit
+// mask; rule groups have no RTMGRP_* constant.
+const networkChangeGroups = unix.RTMGRP_LINK | unix.RTMGRP_IPV4_IFADDR | unix.RTMGRP_IPV6_IFADDR |
+	unix.RTMGRP_IPV4_ROUTE | unix.RTMGRP_IPV6_ROUTE |
+	1<<(unix.RTNLGRP_IPV4_RULE-1) | 1<<(unix.RTNLGRP_IPV6_RULE-1)
+
+// vpnLinkKinds are the link kinds treated as VPN tunnels.
+var vpnLinkKinds = map[string]bool{
+	"wireguard": true,
+	"tun":       true,
+	"ppp":       true,
+	"xfrm":      true,
+	"vti":       true,
+	"vti6":      true,
+}
+
+type monitoredLink struct {
+	name    string
+	kind    string
+	up      bool // IFF_UP
+	running bool // IFF_UP and IFF_RUNNING
+}
+
+// changeMonitorState is the last known state used to turn netlink notifications into changes.
+// The kernel repeats notifications, e.g., for IPv6 address lifetime updates; only real
+// transitions produce changes.
+type changeMonitorState struct {
+	links     map[int]monitoredLink
+	addresses map[string]bool
+	defaults  map[string]anynetwork.Route
+}
+
+// LinuxNetworkChangeMonitor receives link, address, route and rule notifications via rtnetlink.
+type LinuxNetworkChangeMonitor struct{}
+
+// WatchNetworkChanges subscribes to the rtnetlink multicast groups and delivers normalized
+// changes. Routes are reported only for default routes.
+func (m *LinuxNetworkChangeMonitor) WatchNetworkChanges(ctx context.Context) (<-chan anynetwork.NetworkChange, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_RAW|unix.SOCK_CLOEXEC, unix.NETLINK_ROUTE)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open netlink socket: %w", err)
+	}
+	// A larger buffer avoids ENOBUFS on bursts, e.g., when a VPN installs many routes.
+	_ = unix.SetsockoptInt(fd, unix.SOL_SOCKET, unix.SO_RCVBUF, 1<<20)
+	// The receive timeout lets the loop notice the cancelled context.
+	if err := unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &unix.Timeval{Sec: 1}); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("failed to set netlink receive timeout: %w", err)
+	}
+	if err := unix.Bind(fd, &unix.SockaddrNetlink{Family: unix.AF_NETLINK, Groups: networkChangeGroups}); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("failed to subscribe to netlink groups: %w", err)
+	}
+
+	// Seed after subscribing, so that no change between the dump and the first read is lost.
+	state := readChangeMonitorState()
+	changes := make(chan anynetwork.NetworkChange, 64)
+	go func() {
+		defer close(changes)
+		defer unix.Close(fd)
+
+		buf := make([]byte, os.Getpagesize()*16)
+		for ctx.Err() == nil {
+			n, _, err := unix.Recvfrom(fd, buf, 0)
+			if err != nil {
+				switch {
+				case errors.Is(err, unix.EAGAIN), errors.Is(err, unix.EINTR):
+					continue
+				case errors.Is(err, unix.ENOBUFS):
+					log.Println("WARN: Network change monitor lost notifications, resynchronizing.")
+					for _, change := range state.resync(readChangeMonitorState()) {
+						select {
+						case changes <- change:
+						case <-ctx.Done():
+							return
+						}
+					}
+					continue
+				}
+				log.Printf("WARN: Network change monitor stopped: %v", err)
+				return
+			}
+			messages, err := syscall.ParseNetlinkMessage(buf[:n])
+			if err != nil {
+				continue
+			}
+			for _, message := range messages {
+				for _, change := range state.apply(message) {
+					select {
+					case changes <- change:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+	return changes, nil
+}
+
+// NewNetworkChangeMonitor creates the Linux implementation of NetworkChangeMonitor.
+func NewNetworkChangeMonitor() anynetwork.NetworkChangeMonitor {
+	return &LinuxNetworkChangeMonitor{}
+}
+
+// readChangeMonitorState dumps the current links, addresses and default routes.
+func readChangeMonitorState() *changeMonitorState {
+	state := &changeMonitorState{
+		links:     make(map[int]monitoredLink),
+		addresses: make(map[string]bool),
+		defaults:  make(map[string]anynetwork.Route),
+	}
+
+	request := make([]byte, unix.SizeofIfInfomsg)
+	if messages, err := netlinkDump(unix.NETLINK_ROUTE, unix.RTM_GETLINK, request); err == nil {
+		for _, m := range messages {
+			if index, link, ok := parseMonitoredLink(m); ok {
+				state.links[index] = link
+			}
+		}
+	}
+	request = make([]byte, unix.SizeofIfAddrmsg)
+	if messages, err := netlinkDump(unix.NETLINK_ROUTE, unix.RTM_GETADDR, request); err == nil {
+		for _, m := range messages {
+			if _, key, _, ok := parseAddressMessage(m); ok {
+				state.addresses[key] = true
+			}
+		}
+	}
+	request = make([]byte, unix.SizeofRtMsg)
+	if messages, err := netlinkDump(unix.NETLINK_ROUTE, unix.RTM_GETROUTE, request); err == nil {
+		for _, m := range messages {
+			if route, ok := state.parseDefaultRoute(m); ok {
+				state.defaults[defaultRouteKey(route)] = route
+			}
+		}
+	}
+	return state
+}
+
+// apply updates the state with a notification and returns the resulting changes.
+func (s *changeMonitorState) apply(m syscall.NetlinkMessage) []anynetwork.NetworkChange {
+	switch m.Header.Type {
+	case unix.RTM_NEWLINK:
+		index, link, ok := parseMonitoredLink(m)
+		if !ok {
+			return nil
+		}
+		old, known := s.links[index]
+		s.links[index] = link
+		var changes []anynetwork.NetworkChange
+		if !known {
+			change := s.newChange("link-added", index)
+			change.Details = fmt.Sprintf("Interface %s added", link.name)
+			changes = append(changes, change)
+		}
+		if link.running != old.running {
+			changes = append(changes, s.linkStateChange(index))
+		}
+		if old.up && !link.up {
+			changes = append(changes, s.flushDefaultRoutes(index)...)
+		}
+		return changes
+
+	case unix.RTM_DELLINK:
+		index, link, ok := parseMonitoredLink(m)
+		if !ok {
+			return nil
+		}
+		old := s.links[index]
+		link.running = false
+		s.links[index] = link
+		var changes []anynetwork.NetworkChange
+		if old.running {
+			changes = append(changes, s.linkStateChange(index))
+		}
+		changes = append(changes, s.flushDefaultRoutes(index)...)
+		change := s.newChange("link-removed", index)
+		change.Details = fmt.Sprintf("Interface %s removed", link.name)
+		delete(s.links, index)
+		for key := range s.addresses {
+			if strings.HasPrefix(key, fmt.Sprintf("%d|", index)) {
+				delete(s.addresses, key)
+			}
+		}
+		return append(changes, change)
+
+	case unix.RTM_NEWADDR, unix.RTM_DELADDR:
+		index, key, address, ok := parseAddressMessage(m)
+		if !ok {
+			return nil
+		}
+		added := m.Header.Type == unix.RTM_NEWADDR
+		if added == s.addresses[key] {
+			return nil
+		}
+		if added {
+			s.addresses[key] = true
+		} else {
+			delete(s.addresses, key)
+		}
+		return []anynetwork.NetworkChange{s.addressChange(index, address, added)}
+
+	case unix.RTM_NEWROUTE, unix.RTM_DELROUTE:
+		route, ok := s.parseDefaultRoute(m)
+		if !ok {
+			return nil
+		}
+		key := defaultRouteKey(route)
+		_, known := s.defaults[key]
+		added := m.Header.Type == unix.RTM_NEWROUTE
+		if added == known {
+			return nil
+		}
+		if added {
+			s.defaults[key] = route
+		} else {
+			delete(s.defaults, key)
+		}
+		return []anynetwork.NetworkChange{s.defaultRouteChange(route, added)}
+
+	case unix.RTM_NEWRULE, unix.RTM_DELRULE:
+		if len(m.Data) < fibRuleHdrLen {
+			return nil
+		}
+		rule, ok := parseRuleMessage(m.Data)
+		if !ok {
+			return nil
+		}
+		changeType, verb := "rule-removed", "removed"
+		if m.Header.Type == unix.RTM_NEWRULE {
+			changeType, verb = "rule-added", "added"
+		}
+		change := anynetwork.NetworkChange{
+			Timestamp: time.Now().Format(time.RFC3339),
+			Type:      changeType,
+			Family:    rule.Family,
+			Details:   fmt.Sprintf("%s rule %d: %s %s", rule.Family, rule.Priority, describeRoutingRule(rule), verb),
+		}
+		return []anynetwork.NetworkChange{change}
+	}
+	return nil
+}
+
+// resync replaces the state with a fresh dump after notifications were lost and returns the
+// changes between both states, as if the lost notifications had been received. Rule changes
+// are not tracked and cannot be recovered.
+func (s *changeMonitorState) resync(next *changeMonitorState) []anynetwork.NetworkChange {
+	var changes []anynetwork.NetworkChange
+
+	// Removals are described with the old state, which still knows the removed links. Keys
+	// are sorted so that the changes are emitted in a stable order.
+	for _, key := range slices.Sorted(maps.Keys(s.defaults)) {
+		if _, ok := next.defaults[key]; !ok {
+			changes = append(changes, s.defaultRouteChange(s.defaults[key], false))
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(s.addresses)) {
+		if !next.addresses[key] {
+			index, address := splitAddressKey(key)
+			changes = append(changes, s.addressChange(index, address, false))
+		}
+	}
+	for _, index := range slices.Sorted(maps.Keys(s.links)) {
+		if _, ok := next.links[index]; ok {
+			continue
+		}
+		link := s.links[index]
+		if link.running {
+			link.running = false
+			s.links[index] = link
+			changes = append(changes, s.linkStateChange(index))
+		}
+		change := s.newChange("link-removed", index)
+		change.Details = fmt.Sprintf("Interface %s removed", link.name)
+		changes = append(changes, change)
+	}
+
+	// Additions and link state changes are described with the new state.
+	previous := *s
+	s.links, s.addresses, s.defaults = next.links, next.addresses, next.defaults
+	for _, index := range slices.Sorted(maps.Keys(s.links)) {
+		old, known := previous.links[index]
+		if !known {
+			change := s.newChange("link-added", index)
+			change.Details = fmt.Sprintf("Interface %s added", s.links[index].name)
+			changes = append(changes, change)
+		}
+		if s.links[index].running != old.running {
+			changes = append(changes, s.linkStateChange(index))
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(s.addresses)) {
+		if !previous.addresses[key] {
+			index, address := splitAddressKey(key)
+			changes = append(changes, s.addressChange(index, address, true))
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(s.defaults)) {
+		if _, ok := previous.defaults[key]; !ok {
+			changes = append(changes, s.defaultRouteChange(s.defaults[key], true))
+		}
+	}
+	return changes
+}
+
+// linkStateChange creates the up/down change of a link; VPN links yield "vpn-up" and "vpn-down".
+func (s *changeMonitorState) linkStateChange(index int) anynetwork.NetworkChange {
+	link := s.links[index]
+	state := "down"
+	if link.running {
+		state = "up"
+	}
+	prefix := "link-"
+	if vpnLinkKinds[link.kind] {
+		prefix = "vpn-"
+	}
+	change := s.newChange(prefix+state, index)
+	change.Details = fmt.Sprintf("Interface %s is %s", link.name, state)
+	if change.IsVPN {
+		change.Details = fmt.Sprintf("VPN interface %s (%s) is %s", link.name, link.kind, state)
+	}
+	return change
+}
+
+// flushDefaultRoutes removes the default routes of a link that went administratively down or
+// was deleted; the kernel drops them without sending RTM_DELROUTE.
+func (s *changeMonitorState) flushDefaultRoutes(index int) []anynetwork.NetworkChange {
+	var changes []anynetwork.NetworkChange
+	for key, route := range s.defaults {
+		if route.InterfaceIndex == index {
+			delete(s.defaults, key)
+			changes = append(changes, s.defaultRouteChange(route, false))
+		}
+	}
+	return changes
+}
+
+func (s *changeMonitorState) defaultRouteChange(route anynetwork.Route, added bool) anynetwork.NetworkChange {
+	changeType, verb := "default-route-removed", "removed"
+	if added {
+		changeType, verb = "default-route-added", "added"
+	}
+	change := s.newChange(changeType, route.InterfaceIndex)
+	change.Family = route.Family
+	change.Address = route.Gateway
+	via := ""
+	if route.Gateway != "" {
+		via = " via " + route.Gateway
+	}
+	change.Details = fmt.Sprintf("%s default route%s on %s (metric %d, table %s) %s",
+		route.Family, via, route.Interface, route.Metric, route.Table, verb)
+	return change
+}
+
+// addressChange creates the change of an address in CIDR notation added to or removed from a link.
+func (s *changeMonitorState) addressChange(index int, address string, added bool) anynetwork.NetworkChange {
+	changeType, verb := "address-removed", "removed from"
+	if added {
+		changeType, verb = "address-added", "added to"
+	}
+	change := s.newChange(changeType, index)
+	change.Family = "IPv4"
+	if strings.Contains(address, ":") {
+		change.Family = "IPv6"
+	}
+	change.Address = address
+	change.Details = fmt.Sprintf("Address %s %s %s", address, verb, change.Interface)
+	return change
+}
+
+func (s *changeMonitorState) newChange(changeType string, index int) anynetwork.NetworkChange {
+	link := s.links[index]
+	if link.name == "" {
+		link.name = s.interfaceName(index)
+	}
+	return anynetwork.NetworkChange{
+		Timestamp:      time.Now().Format(time.RFC3339),
+		Type:           changeType,
+		Interface:      link.name,
+		InterfaceIndex: index,
+		Kind:           link.kind,
+		IsVPN:          vpnLinkKinds[link.kind],
+	}
+}
+
+func (s *changeMonitorState) interfaceName(index int) string {
+	if link, ok := s.links[index]; ok {
+		return link.name
+	}
+	if iface, err := net.InterfaceByIndex(index); err == nil {
+		return iface.Name
+	}
+	return ""
+}
+
+// parseDefaultRoute parses a route message and accepts default routes outside the local table.
+func (s *changeMonitorState) parseDefaultRoute(m syscall.NetlinkMessage) (anynetwork.Route, bool) {
+	if (m.Header.Type != unix.RTM_NEWROUTE && m.Header.Type != unix.RTM_DELROUTE) || len(m.Data) < unix.SizeofRtMsg {
+		return anynetwork.Route{}, false
+	}
+	if binary.NativeEndian.Uint32(m.Data[8:12])&unix.RTM_F_CLONED != 0 {
+		return anynetwork.Route{}, false
+	}
+	route, ok := parseRouteMessage(m.Data, s.interfaceName)
+	if !ok || !route.IsDefault || route.TableID == unix.RT_TABLE_LOCAL {
+		return anynetwork.Route{}, false
+	}
+	return route, true
+}
+
+func defaultRouteKey(route anynetwork.Route) string {
+	return fmt.Sprintf("%s|%d|%s|%d|%d", route.Family, route.TableID, route.Gateway, route.InterfaceIndex, route.Metric)
+}
+
+// parseMonitoredLink parses the index, name, kind and running state of an ifinfomsg.
+func parseMonitoredLink(m syscall.NetlinkMessage) (int, monitoredLink, bool) {
+	if (m.Header.Type != unix.RTM_NEWLINK && m.Header.Type != unix.RTM_DELLINK) || len(m.Data) < unix.SizeofIfInfomsg {
+		return 0, monitoredLink{}, false
+	}
+	index := int(int32(binary.NativeEndian.Uint32(m.Data[4:8])))
+	flags := binary.NativeEndian.Uint32(m.Data[8:12])
+	attrs := netlinkAttributeMap(m.Data[unix.SizeofIfInfomsg:])
+
+	link := monitoredLink{
+		name:    strings.TrimRight(string(attrs[unix.IFLA_IFNAME]), "\x00"),
+		up:      flags&unix.IFF_UP != 0,
+		running: flags&unix.IFF_UP != 0 && flags&unix.IFF_RUNNING != 0,
+	}
+	if linkInfo, ok := attrs[unix.IFLA_LINKINFO]; ok {
+		link.kind = strings.TrimRight(string(netlinkAttributeMap(linkInfo)[unix.IFLA_INFO_KIND]), "\x00")
+	}
+	return index, link, true
+}
+
+// parseAddressMessage parses an ifaddrmsg and returns the interface index, a state key and
+// the address in CIDR notation.
+func parseAddressMessage(m syscall.NetlinkMessage) (int, string, string, bool) {
+	index, addr, ok := parseInterfaceAddressMessage(m)
+	if !ok {
+		return 0, "", "", false
+	}
+	address := fmt.Sprintf("%s/%d", addr.IP, addr.PrefixLength)
+	return index, fmt.Sprintf("%d|%s", index, address), address, true
+}
+
+// splitAddressKey splits a state key of parseAddressMessage into interface index and address.
+func splitAddressKey(key string) (int, string) {
+	indexText, address, _ := strings.Cut(key, "|")
+	index, _ := strconv.Atoi(indexText)
+	return index, address
+}
+
+// describeRoutingRule formats a rule like "ip rule", e.g., "from all fwmark 0xca6c lookup 51820".
+func describeRoutingRule(rule anynetwork.RoutingRule) string {
+	var parts []string
+	if rule.Invert {
+		parts = append(parts, "not")
+	}
+	source := rule.Source
+	if source == "" {
+		source = "all"
+	}
+	parts = append(parts, "from "+source)
+	if rule.Destination != "" {
+		parts = append(parts, "to "+rule.Destination)
+	}
+	if rule.FwMark != 0 || rule.FwMask != 0 {
+		parts = append(parts, fmt.Sprintf("fwmark 0x%x", rule.FwMark))
+	}
+	if rule.InputInterface != "" {
+		parts = append(parts, "iif "+rule.InputInterface)
+	}
+	if rule.OutputInterface != "" {
+		parts = append(parts, "oif "+rule.OutputInterface)
+	}
+	switch rule.Action {
+	case "lookup":
+		parts = append(parts, "lookup "+rule.Table)
+	case "goto":
+		parts = append(parts, fmt.Sprintf("goto %d", rule.Goto))
+	default:
+		parts = append(parts, rule.Action)
+	}
+	return strings.Join(parts, " ")
+}
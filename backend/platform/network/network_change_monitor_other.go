@@ -0,0 +1,12 @@
+//go:build !linux
+
+package network
+
+import (
+	anynetwork "privacy-buddy/backend/network"
+)
+
+// NewNetworkChangeMonitor returns a monitor that reports change notifications as not supported.
+func NewNetworkChangeMonitor() anynetwork.NetworkChangeMonitor {
+	return anynetwork.UnsupportedNetworkChangeMonitor{}
+}
@@ -35,7 +35,7 @@ func (s *DarwinNetworkConnectionService) GetConnections() ([]anynetwork.NetworkC
 		}
 
 		netConnections = append(netConnections, anynetwork.NetworkConnection{
-			FD:          conn.Fd,
+			FD:          uint64(conn.Fd),
 			Family:      conn.Family,
 			Type:        conn.Type,
 			LocalIP:     conn.Laddr.IP,
@@ -51,4 +51,7 @@ func (s *DarwinNetworkConnectionService) GetConnections() ([]anynetwork.NetworkC
 	return netConnections, nil
 }
 
-
+// NewNetworkConnectionService creates the Darwin implementation of NetworkConnectionService.
+func NewNetworkConnectionService() anynetwork.NetworkConnectionService {
+	return &DarwinNetworkConnectionService{}
+}
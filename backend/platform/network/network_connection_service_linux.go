@@ -5,48 +5,23 @@ package network
 import (
 	"fmt"
 
-	"github.com/shirou/gopsutil/v3/net"
-	"github.com/shirou/gopsutil/v3/process"
-
 	anynetwork "privacy-buddy/backend/network"
 )
 
 // LinuxNetworkConnectionService provides Linux-specific implementation for listing network connections.
 type LinuxNetworkConnectionService struct{}
 
-// GetConnections lists all active network connections on Linux.
+// GetConnections lists all TCP, UDP and raw sockets on Linux. The socket tables are read
+// directly from /proc/net and mapped to processes with one pass over /proc/*/fd.
 func (s *LinuxNetworkConnectionService) GetConnections() ([]anynetwork.NetworkConnection, error) {
-	connections, err := net.Connections("all")
+	connections, err := readProcNetConnections()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get network connections: %w", err)
 	}
+	return connections, nil
+}
 
-	var netConnections []anynetwork.NetworkConnection
-	for _, conn := range connections {
-		processName := "N/A"
-		if conn.Pid != 0 {
-			proc, err := process.NewProcess(conn.Pid)
-			if err == nil {
-				name, err := proc.Name()
-				if err == nil {
-					processName = name
-				}
-			}
-		}
-
-		netConnections = append(netConnections, anynetwork.NetworkConnection{
-			FD:          uint64(conn.Fd),
-			Family:      conn.Family,
-			Type:        conn.Type,
-			LocalIP:     conn.Laddr.IP,
-			LocalPort:   conn.Laddr.Port,
-			RemoteIP:    conn.Raddr.IP,
-			RemotePort:  conn.Raddr.Port,
-			Status:      conn.Status,
-			PID:         conn.Pid,
-			ProcessName: processName,
-		})
-	}
-
-	return netConnections, nil
+// NewNetworkConnectionService creates the Linux implementation of NetworkConnectionService.
+func NewNetworkConnectionService() anynetwork.NetworkConnectionService {
+	return &LinuxNetworkConnectionService{}
 }
@@ -50,3 +50,8 @@ func (s *WindowsNetworkConnectionService) GetConnections() ([]anynetwork.Network
 
 	return netConnections, nil
 }
+
+// NewNetworkConnectionService creates the Windows implementation of NetworkConnectionService.
+func NewNetworkConnectionService() anynetwork.NetworkConnectionService {
+	return &WindowsNetworkConnectionService{}
+}
@@ -27,7 +27,7 @@ func (s *DarwinPacketCaptureService) StartCapture(ctx context.Context, interface
 	}
 
 	// Open the device for capturing
-	handle, err := pcap.OpenLive(interfaceName, 1600, true, pcap.NextPacket)
+	handle, err := pcap.OpenLive(interfaceName, 1600, true, pcap.BlockForever)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open device %s: %w", interfaceName, err)
 	}
@@ -44,7 +44,7 @@ func (s *DarwinPacketCaptureService) StartCapture(ctx context.Context, interface
 	}
 
 	packetSource := gopacket.NewPacketSource(s.handle, s.handle.LinkType())
-	packetChannel := make(chan CapturedPacket)
+	packetChannel := make(chan anynetwork.CapturedPacket)
 
 	go func() {
 		defer close(packetChannel)
@@ -89,3 +89,7 @@ func (s *DarwinPacketCaptureService) StopCapture() error {
 	return fmt.Errorf("no active capture to stop")
 }
 
+// NewPacketCaptureService creates the Darwin implementation of PacketCaptureService.
+func NewPacketCaptureService() anynetwork.PacketCaptureService {
+	return &DarwinPacketCaptureService{}
+}
@@ -87,3 +87,8 @@ func (s *LinuxPacketCaptureService) StopCapture() error {
 	}
 	return fmt.Errorf("no active capture to stop")
 }
+
+// NewPacketCaptureService creates the Linux implementation of PacketCaptureService.
+func NewPacketCaptureService() anynetwork.PacketCaptureService {
+	return &LinuxPacketCaptureService{}
+}
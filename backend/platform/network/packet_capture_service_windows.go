@@ -129,3 +129,8 @@ func (s *WindowsPacketCaptureService) StopCapture() error {
 	}
 	return fmt.Errorf("no active capture to stop")
 }
+
+// NewPacketCaptureService creates the Windows implementation of PacketCaptureService.
+func NewPacketCaptureService() anynetwork.PacketCaptureService {
+	return &WindowsPacketCaptureService{}
+}
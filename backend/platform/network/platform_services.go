@@ -0,0 +1,22 @@
+package network
+
+import (
+	anynetwork "privacy-buddy/backend/network"
+)
+
+// NewPlatformServices builds the service implementations for the current operating system.
+// Each constructor is provided by the build-tagged files of this package.
+func NewPlatformServices() anynetwork.PlatformServices {
+	return anynetwork.PlatformServices{
+		ARPCache:          NewARPCacheService(),
+		NetworkInterfaces: NewNetworkInterfaceService(),
+		Connections:       NewNetworkConnectionService(),
+		PacketCapture:     NewPacketCaptureService(),
+		LocalSockets:      NewLocalSocketService(),
+		InterfaceCounters: NewInterfaceCounterService(),
+		Wireless:          NewWirelessService(),
+		Routing:           NewRoutingService(),
+		DNSConfig:         NewDNSConfigService(),
+		ChangeMonitor:     NewNetworkChangeMonitor(),
+	}.WithDefaults()
+}
@@ -0,0 +1,333 @@
+//go:build linux
+
+package network
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	anynetwork "privacy-buddy/backend/network"
+)
+
+// clockTicksPerSecond is USER_HZ, the unit of the timer column in /proc/net/*.
+// It is 100 on all architectures supported by Linux.
+const clockTicksPerSecond = 100
+
+// procNetTable describes one socket table file below /proc/net.
+type procNetTable struct {
+	name     string
+	protocol string
+	family   uint32
+	sockType uint32
+}
+
+var procNetTables = []procNetTable{
+	{name: "tcp", protocol: "tcp", family: unix.AF_INET, sockType: unix.SOCK_STREAM},
+	{name: "tcp6", protocol: "tcp", family: unix.AF_INET6, sockType: unix.SOCK_STREAM},
+	{name: "udp", protocol: "udp", family: unix.AF_INET, sockType: unix.SOCK_DGRAM},
+	{name: "udp6", protocol: "udp", family: unix.AF_INET6, sockType: unix.SOCK_DGRAM},
+	{name: "raw", protocol: "raw", family: unix.AF_INET, sockType: unix.SOCK_RAW},
+	{name: "raw6", protocol: "raw", family: unix.AF_INET6, sockType: unix.SOCK_RAW},
+}
+
+// tcpStates maps the kernel TCP states (include/net/tcp_states.h) to their names.
+var tcpStates = map[uint64]string{
+	0x01: "ESTABLISHED",
+	0x02: "SYN_SENT",
+	0x03: "SYN_RECV",
+	0x04: "FIN_WAIT1",
+	0x05: "FIN_WAIT2",
+	0x06: "TIME_WAIT",
+	0x07: "CLOSE",
+	0x08: "CLOSE_WAIT",
+	0x09: "LAST_ACK",
+	0x0A: "LISTEN",
+	0x0B: "CLOSING",
+	0x0C: "NEW_SYN_RECV",
+}
+
+// socketTimers maps the "tr" column to the active timer.
+var socketTimers = map[uint64]string{
+	0: "off",
+	1: "retransmit",
+	2: "keepalive",
+	3: "time_wait",
+	4: "zero_window_probe",
+}
+
+// socketOwner is the process and file descriptor that hold a socket inode.
+type socketOwner struct {
+	pid int32
+	fd  uint64
+}
+
+// readProcNetConnections reads all TCP, UDP and raw sockets from /proc/net and
+// resolves their owning processes with a single pass over /proc/*/fd.
+func readProcNetConnections() ([]anynetwork.NetworkConnection, error) {
+	var connections []anynetwork.NetworkConnection
+	read := 0
+	for _, table := range procNetTables {
+		conns, err := parseProcNetFile(filepath.Join("/proc/net", table.name), table)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue // e.g., IPv6 disabled
+			}
+			return nil, err
+		}
+		read++
+		connections = append(connections, conns...)
+	}
+	if read == 0 {
+		return nil, fmt.Errorf("no socket tables found in /proc/net")
+	}
+
+	inodes := make(map[uint64]struct{}, len(connections))
+	for _, conn := range connections {
+		if conn.Inode != 0 {
+			inodes[conn.Inode] = struct{}{}
+		}
+	}
+	owners := socketInodeOwners(inodes)
+	names := newProcessCommCache()
+	for i := range connections {
+		connections[i].ProcessName = "N/A"
+		owner, ok := owners[connections[i].Inode]
+		if !ok {
+			continue
+		}
+		connections[i].PID = owner.pid
+		connections[i].FD = owner.fd
+		if name := names(owner.pid); name != "" {
+			connections[i].ProcessName = name
+		}
+	}
+	return connections, nil
+}
+
+// parseProcNetFile parses one socket table, skipping the header line. Unparsable lines
+// are logged and skipped so that a single bad entry does not hide the whole table.
+func parseProcNetFile(path string, table procNetTable) ([]anynetwork.NetworkConnection, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var connections []anynetwork.NetworkConnection
+	scanner := bufio.NewScanner(file)
+	scanner.Scan() // Header
+	for scanner.Scan() {
+		conn, err := parseProcNetLine(scanner.Text(), table)
+		if err != nil {
+			log.Printf("WARN: Skipping entry of %s: %v", path, err)
+			continue
+		}
+		connections = append(connections, conn)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return connections, nil
+}
+
+// parseProcNetLine parses a line like
+// "0: 0100007F:0277 00000000:0000 0A 00000000:00000000 00:00000000 00000000 0 0 12345 ...".
+func parseProcNetLine(line string, table procNetTable) (anynetwork.NetworkConnection, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 10 {
+		return anynetwork.NetworkConnection{}, fmt.Errorf("unexpected line %q", line)
+	}
+
+	localIP, localPort, err := parseProcNetAddress(fields[1])
+	if err != nil {
+		return anynetwork.NetworkConnection{}, err
+	}
+	remoteIP, remotePort, err := parseProcNetAddress(fields[2])
+	if err != nil {
+		return anynetwork.NetworkConnection{}, err
+	}
+	state, err := strconv.ParseUint(fields[3], 16, 8)
+	if err != nil {
+		return anynetwork.NetworkConnection{}, fmt.Errorf("invalid state %q", fields[3])
+	}
+	txQueue, rxQueue, err := parseHexPair(fields[4])
+	if err != nil {
+		return anynetwork.NetworkConnection{}, err
+	}
+	timer, when, err := parseHexPair(fields[5])
+	if err != nil {
+		return anynetwork.NetworkConnection{}, err
+	}
+	retransmits, _ := strconv.ParseUint(fields[6], 16, 32)
+	uid, _ := strconv.ParseUint(fields[7], 10, 32)
+	inode, _ := strconv.ParseUint(fields[9], 10, 64)
+
+	status := "NONE"
+	if table.sockType == unix.SOCK_STREAM {
+		status = tcpStates[state]
+	}
+
+	conn := anynetwork.NetworkConnection{
+		Family:      table.family,
+		Type:        table.sockType,
+		LocalIP:     localIP,
+		LocalPort:   localPort,
+		RemoteIP:    remoteIP,
+		RemotePort:  remotePort,
+		Status:      status,
+		Protocol:    table.protocol,
+		FamilyName:  familyName(table.family),
+		TypeName:    socketTypeName(table.sockType),
+		UID:         uint32(uid),
+		Inode:       inode,
+		TxQueue:     txQueue,
+		RxQueue:     rxQueue,
+		Timer:       socketTimers[timer],
+		Retransmits: uint32(retransmits),
+	}
+	if timer != 0 {
+		conn.TimerExpiresMs = int64(when) * 1000 / clockTicksPerSecond
+	}
+	return conn, nil
+}
+
+// parseProcNetAddress decodes "0100007F:0277" or the 32 hex digit IPv6 form. The address
+// is stored as 32-bit words in host byte order, the port in network byte order.
+func parseProcNetAddress(s string) (string, uint32, error) {
+	addr, portHex, ok := strings.Cut(s, ":")
+	if !ok {
+		return "", 0, fmt.Errorf("invalid address %q", s)
+	}
+	raw, err := hex.DecodeString(addr)
+	if err != nil || (len(raw) != net.IPv4len && len(raw) != net.IPv6len) {
+		return "", 0, fmt.Errorf("invalid address %q", s)
+	}
+	port, err := strconv.ParseUint(portHex, 16, 16)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid port in %q", s)
+	}
+
+	ip := make(net.IP, len(raw))
+	for i := 0; i < len(raw); i += 4 {
+		binary.BigEndian.PutUint32(ip[i:], binary.NativeEndian.Uint32(raw[i:]))
+	}
+	return ip.String(), uint32(port), nil
+}
+
+// parseHexPair parses "0000001A:00000000" into its two values.
+func parseHexPair(s string) (uint64, uint64, error) {
+	first, second, ok := strings.Cut(s, ":")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid field %q", s)
+	}
+	a, err := strconv.ParseUint(first, 16, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid field %q", s)
+	}
+	b, err := strconv.ParseUint(second, 16, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid field %q", s)
+	}
+	return a, b, nil
+}
+
+// socketInodeOwners maps socket inodes to the first process/fd holding them. The walk
+// stops as soon as all wanted inodes are found. Processes of other users are only
+// visible with sufficient privileges.
+func socketInodeOwners(wanted map[uint64]struct{}) map[uint64]socketOwner {
+	owners := make(map[uint64]socketOwner, len(wanted))
+	procEntries, err := os.ReadDir("/proc")
+	if err != nil {
+		return owners
+	}
+
+	for _, procEntry := range procEntries {
+		pid, err := strconv.ParseInt(procEntry.Name(), 10, 32)
+		if err != nil {
+			continue
+		}
+		fdDir := filepath.Join("/proc", procEntry.Name(), "fd")
+		fdEntries, err := os.ReadDir(fdDir)
+		if err != nil {
+			continue // Process exited or access denied
+		}
+		for _, fdEntry := range fdEntries {
+			target, err := os.Readlink(filepath.Join(fdDir, fdEntry.Name()))
+			if err != nil || !strings.HasPrefix(target, "socket:[") {
+				continue
+			}
+			inode, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(target, "socket:["), "]"), 10, 64)
+			if err != nil {
+				continue
+			}
+			if _, ok := wanted[inode]; !ok {
+				continue
+			}
+			if _, ok := owners[inode]; ok {
+				continue
+			}
+			fd, _ := strconv.ParseUint(fdEntry.Name(), 10, 64)
+			owners[inode] = socketOwner{pid: int32(pid), fd: fd}
+		}
+		if len(owners) == len(wanted) {
+			break
+		}
+	}
+	return owners
+}
+
+// newProcessCommCache returns a processComm lookup that reads each PID only once.
+func newProcessCommCache() func(pid int32) string {
+	names := make(map[int32]string)
+	return func(pid int32) string {
+		name, cached := names[pid]
+		if !cached {
+			name = processComm(pid)
+			names[pid] = name
+		}
+		return name
+	}
+}
+
+// processComm returns the command name of a process from /proc/<pid>/comm.
+func processComm(pid int32) string {
+	data, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(int(pid)), "comm"))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func familyName(family uint32) string {
+	switch family {
+	case unix.AF_INET:
+		return "IPv4"
+	case unix.AF_INET6:
+		return "IPv6"
+	}
+	return strconv.FormatUint(uint64(family), 10)
+}
+
+func socketTypeName(sockType uint32) string {
+	switch sockType {
+	case unix.SOCK_STREAM:
+		return "SOCK_STREAM"
+	case unix.SOCK_DGRAM:
+		return "SOCK_DGRAM"
+	case unix.SOCK_RAW:
+		return "SOCK_RAW"
+	case unix.SOCK_SEQPACKET:
+		return "SOCK_SEQPACKET"
+	}
+	return strconv.FormatUint(uint64(sockType), 10)
+}
@@ -0,0 +1,175 @@
+//go:build linux
+
+package network
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+// The fixtures below were captured on little-endian machines, where /proc/net stores
+// each 32-bit address word in little-endian byte order.
+func skipOnBigEndian(t *testing.T) {
+	t.Helper()
+	if binary.NativeEndian.Uint16([]byte{1, 0}) != 1 {
+		t.Skip("fixtures use little-endian address words")
+	}
+}
+
+func TestParseProcNetAddress(t *testing.T) {
+	skipOnBigEndian(t)
+	tests := []struct {
+		input    string
+		wantIP   string
+		wantPort uint32
+		wantErr  bool
+	}{
+		{input: "0100007F:0277", wantIP: "127.0.0.1", wantPort: 631},
+		{input: "3500007F:0035", wantIP: "127.0.0.53", wantPort: 53},
+		{input: "00000000:0000", wantIP: "0.0.0.0", wantPort: 0},
+		{input: "0101A8C0:E3A2", wantIP: "192.168.1.1", wantPort: 58274},
+		{input: "00000000000000000000000000000000:0016", wantIP: "::", wantPort: 22},
+		{input: "00000000000000000000000001000000:0277", wantIP: "::1", wantPort: 631},
+		{input: "0000000000000000FFFF00000100007F:1F90", wantIP: "127.0.0.1", wantPort: 8080},
+		{input: "B80D0120000000000000000001000000:01BB", wantIP: "2001:db8::1", wantPort: 443},
+		{input: "0100007F", wantErr: true},
+		{input: "0100007:0277", wantErr: true},
+		{input: "0100007F00:0277", wantErr: true},
+		{input: "0100007F:10000", wantErr: true},
+		{input: "0100007G:0277", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			ip, port, err := parseProcNetAddress(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseProcNetAddress(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if ip != tt.wantIP || port != tt.wantPort {
+				t.Errorf("parseProcNetAddress(%q) = %q, %d; want %q, %d", tt.input, ip, port, tt.wantIP, tt.wantPort)
+			}
+		})
+	}
+}
+
+func TestParseProcNetLine(t *testing.T) {
+	skipOnBigEndian(t)
+	tcp6 := procNetTable{name: "tcp6", protocol: "tcp", family: unix.AF_INET6, sockType: unix.SOCK_STREAM}
+	udp := procNetTable{name: "udp", protocol: "udp", family: unix.AF_INET, sockType: unix.SOCK_DGRAM}
+
+	tests := []struct {
+		name    string
+		line    string
+		table   procNetTable
+		check   func(t *testing.T, conn connectionFields)
+		wantErr bool
+	}{
+		{
+			name:  "tcp6 listen",
+			line:  "   0: 00000000000000000000000000000000:0016 00000000000000000000000000000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 23456 1 0000000000000000 100 0 0 10 0",
+			table: tcp6,
+			check: expectConnection(connectionFields{
+				localIP: "::", localPort: 22, remoteIP: "::", remotePort: 0,
+				status: "LISTEN", protocol: "tcp", uid: 0, inode: 23456, timer: "off",
+			}),
+		},
+		{
+			name:  "tcp6 established IPv4-mapped with keepalive",
+			line:  "   1: 0000000000000000FFFF00000100007F:9C40 0000000000000000FFFF00000100007F:1F90 01 0000001A:00000002 02:000005DC 00000003  1000        0 34567 2 0000000000000000 20 4 30 10 -1",
+			table: tcp6,
+			check: expectConnection(connectionFields{
+				localIP: "127.0.0.1", localPort: 40000, remoteIP: "127.0.0.1", remotePort: 8080,
+				status: "ESTABLISHED", protocol: "tcp", uid: 1000, inode: 34567,
+				txQueue: 26, rxQueue: 2, timer: "keepalive", timerExpiresMs: 15000, retransmits: 3,
+			}),
+		},
+		{
+			name:  "tcp6 global address",
+			line:  "   2: B80D0120000000000000000001000000:01BB B80D0120000000000000000002000000:D431 06 00000000:00000000 03:00001770 00000000     0        0 0 3 0000000000000000",
+			table: tcp6,
+			check: expectConnection(connectionFields{
+				localIP: "2001:db8::1", localPort: 443, remoteIP: "2001:db8::2", remotePort: 54321,
+				status: "TIME_WAIT", protocol: "tcp", timer: "time_wait", timerExpiresMs: 60000,
+			}),
+		},
+		{
+			name:  "udp resolver",
+			line:  "  123: 3500007F:0035 00000000:0000 07 00000000:00000000 00:00000000 00000000   101        0 15789 2 0000000000000000 0",
+			table: udp,
+			check: expectConnection(connectionFields{
+				localIP: "127.0.0.53", localPort: 53, remoteIP: "0.0.0.0", remotePort: 0,
+				status: "NONE", protocol: "udp", uid: 101, inode: 15789, timer: "off",
+			}),
+		},
+		{
+			name:  "udp connected",
+			line:  " 4242: 0F01A8C0:A1B2 0101A8C0:0035 01 00000000:00000300 00:00000000 00000000  1000        0 98765 2 0000000000000000 7",
+			table: udp,
+			check: expectConnection(connectionFields{
+				localIP: "192.168.1.15", localPort: 41394, remoteIP: "192.168.1.1", remotePort: 53,
+				status: "NONE", protocol: "udp", uid: 1000, inode: 98765, rxQueue: 768, timer: "off",
+			}),
+		},
+		{
+			name:    "truncated line",
+			line:    "   0: 0100007F:0277 00000000:0000 0A",
+			table:   udp,
+			wantErr: true,
+		},
+		{
+			name:    "invalid address",
+			line:    "   0: XX00007F:0277 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 1 1",
+			table:   udp,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, err := parseProcNetLine(tt.line, tt.table)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseProcNetLine() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if conn.Family != tt.table.family || conn.Type != tt.table.sockType {
+				t.Errorf("family/type = %d/%d, want %d/%d", conn.Family, conn.Type, tt.table.family, tt.table.sockType)
+			}
+			tt.check(t, connectionFields{
+				localIP: conn.LocalIP, localPort: conn.LocalPort, remoteIP: conn.RemoteIP, remotePort: conn.RemotePort,
+				status: conn.Status, protocol: conn.Protocol, uid: conn.UID, inode: conn.Inode,
+				txQueue: conn.TxQueue, rxQueue: conn.RxQueue, timer: conn.Timer,
+				timerExpiresMs: conn.TimerExpiresMs, retransmits: conn.Retransmits,
+			})
+		})
+	}
+}
+
+// connectionFields are the NetworkConnection fields filled by parseProcNetLine.
+type connectionFields struct {
+	localIP        string
+	localPort      uint32
+	remoteIP       string
+	remotePort     uint32
+	status         string
+	protocol       string
+	uid            uint32
+	inode          uint64
+	txQueue        uint64
+	rxQueue        uint64
+	timer          string
+	timerExpiresMs int64
+	retransmits    uint32
+}
+
+func expectConnection(want connectionFields) func(t *testing.T, got connectionFields) {
+	return func(t *testing.T, got connectionFields) {
+		t.Helper()
+		if got != want {
+			t.Errorf("got  %+v\nwant %+v", got, want)
+		}
+	}
+}
@@ -0,0 +1,334 @@
+//go:build linux
+
+package network
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	anynetwork "privacy-buddy/backend/network"
+)
+
+// fibRuleHdrLen is sizeof(struct fib_rule_hdr), which x/sys/unix does not export.
+const fibRuleHdrLen = 12
+
+var routeTableNames = map[uint32]string{
+	unix.RT_TABLE_DEFAULT: "default",
+	unix.RT_TABLE_MAIN:    "main",
+	unix.RT_TABLE_LOCAL:   "local",
+}
+
+var routeProtocolNames = map[uint8]string{
+	unix.RTPROT_UNSPEC:     "unspec",
+	unix.RTPROT_REDIRECT:   "redirect",
+	unix.RTPROT_KERNEL:     "kernel",
+	unix.RTPROT_BOOT:       "boot",
+	unix.RTPROT_STATIC:     "static",
+	unix.RTPROT_RA:         "ra",
+	unix.RTPROT_ZEBRA:      "zebra",
+	unix.RTPROT_BIRD:       "bird",
+	unix.RTPROT_DHCP:       "dhcp",
+	unix.RTPROT_KEEPALIVED: "keepalived",
+	unix.RTPROT_BABEL:      "babel",
+	unix.RTPROT_BGP:        "bgp",
+	unix.RTPROT_ISIS:       "isis",
+	unix.RTPROT_OSPF:       "ospf",
+	unix.RTPROT_RIP:        "rip",
+}
+
+var routeScopeNames = map[uint8]string{
+	unix.RT_SCOPE_UNIVERSE: "universe",
+	unix.RT_SCOPE_SITE:     "site",
+	unix.RT_SCOPE_LINK:     "link",
+	unix.RT_SCOPE_HOST:     "host",
+	unix.RT_SCOPE_NOWHERE:  "nowhere",
+}
+
+var routeTypeNames = map[uint8]string{
+	unix.RTN_UNICAST:     "unicast",
+	unix.RTN_LOCAL:       "local",
+	unix.RTN_BROADCAST:   "broadcast",
+	unix.RTN_ANYCAST:     "anycast",
+	unix.RTN_MULTICAST:   "multicast",
+	unix.RTN_BLACKHOLE:   "blackhole",
+	unix.RTN_UNREACHABLE: "unreachable",
+	unix.RTN_PROHIBIT:    "prohibit",
+	unix.RTN_THROW:       "throw",
+	unix.RTN_NAT:         "nat",
+}
+
+var ruleActionNames = map[uint8]string{
+	unix.FR_ACT_TO_TBL:      "lookup",
+	unix.FR_ACT_GOTO:        "goto",
+	unix.FR_ACT_NOP:         "nop",
+	unix.FR_ACT_BLACKHOLE:   "blackhole",
+	unix.FR_ACT_UNREACHABLE: "unreachable",
+	unix.FR_ACT_PROHIBIT:    "prohibit",
+}
+
+// LinuxRoutingService reads routes and policy rules via rtnetlink.
+type LinuxRoutingService struct{}
+
+// GetRoutes dumps the IPv4 and IPv6 routes of all tables. Cached (cloned) routes are skipped.
+func (s *LinuxRoutingService) GetRoutes() ([]anynetwork.Route, error) {
+	request := make([]byte, unix.SizeofRtMsg)
+	request[0] = unix.AF_UNSPEC
+	messages, err := netlinkDump(unix.NETLINK_ROUTE, unix.RTM_GETROUTE, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get routes: %w", err)
+	}
+
+	interfaceName := interfaceNamesByIndex()
+	var routes []anynetwork.Route
+	for _, m := range messages {
+		if m.Header.Type != unix.RTM_NEWROUTE || len(m.Data) < unix.SizeofRtMsg {
+			continue
+		}
+		if binary.NativeEndian.Uint32(m.Data[8:12])&unix.RTM_F_CLONED != 0 {
+			continue
+		}
+		if route, ok := parseRouteMessage(m.Data, interfaceName); ok {
+			routes = append(routes, route)
+		}
+	}
+	return routes, nil
+}
+
+// GetRoutingRules dumps the IPv4 and IPv6 policy routing rules; the kernel returns them
+// in priority order per family.
+func (s *LinuxRoutingService) GetRoutingRules() ([]anynetwork.RoutingRule, error) {
+	request := make([]byte, fibRuleHdrLen)
+	request[0] = unix.AF_UNSPEC
+	messages, err := netlinkDump(unix.NETLINK_ROUTE, unix.RTM_GETRULE, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get routing rules: %w", err)
+	}
+
+	var rules []anynetwork.RoutingRule
+	for _, m := range messages {
+		if m.Header.Type != unix.RTM_NEWRULE || len(m.Data) < fibRuleHdrLen {
+			continue
+		}
+		if rule, ok := parseRuleMessage(m.Data); ok {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+// LookupRoute asks the kernel which route and source address it would use for a
+// destination IP address or hostname, like "ip route get".
+func (s *LinuxRoutingService) LookupRoute(destination string) (*anynetwork.RouteLookup, error) {
+	ip := net.ParseIP(strings.TrimSpace(destination))
+	if ip == nil {
+		ips, err := net.LookupIP(strings.TrimSpace(destination))
+		if err != nil || len(ips) == 0 {
+			return nil, fmt.Errorf("invalid destination '%s': not an IP address or resolvable hostname", destination)
+		}
+		ip = ips[0]
+	}
+
+	interfaceName := interfaceNamesByIndex()
+	resolved, err := routeGet(ip, 0, interfaceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up route to %s: %w", ip, err)
+	}
+	lookup := &anynetwork.RouteLookup{
+		Destination:   ip.String(),
+		Route:         resolved,
+		SourceAddress: resolved.PreferredSource,
+	}
+	// RTM_F_FIB_MATCH (Linux 4.13+) returns the table entry instead of a host route.
+	if matched, err := routeGet(ip, unix.RTM_F_FIB_MATCH, interfaceName); err == nil {
+		lookup.Route = matched
+	}
+	return lookup, nil
+}
+
+// NewRoutingService creates the Linux implementation of RoutingService.
+func NewRoutingService() anynetwork.RoutingService {
+	return &LinuxRoutingService{}
+}
+
+// routeGet sends an RTM_GETROUTE request for a single destination.
+func routeGet(ip net.IP, flags uint32, interfaceName func(int) string) (anynetwork.Route, error) {
+	family, addr := byte(unix.AF_INET6), ip.To16()
+	if ip4 := ip.To4(); ip4 != nil {
+		family, addr = unix.AF_INET, ip4
+	}
+	request := make([]byte, unix.SizeofRtMsg)
+	request[0] = family
+	request[1] = byte(len(addr) * 8)
+	binary.NativeEndian.PutUint32(request[8:12], flags)
+	request = append(request, netlinkAttributeBytes(unix.RTA_DST, addr)...)
+
+	messages, err := netlinkRoundTrip(unix.NETLINK_ROUTE, unix.RTM_GETROUTE, unix.NLM_F_REQUEST, request)
+	if err != nil {
+		return anynetwork.Route{}, err
+	}
+	for _, m := range messages {
+		if m.Header.Type != unix.RTM_NEWROUTE || len(m.Data) < unix.SizeofRtMsg {
+			continue
+		}
+		if route, ok := parseRouteMessage(m.Data, interfaceName); ok {
+			return route, nil
+		}
+	}
+	return anynetwork.Route{}, fmt.Errorf("no route returned")
+}
+
+// parseRouteMessage parses an rtmsg with its attributes.
+func parseRouteMessage(data []byte, interfaceName func(int) string) (anynetwork.Route, bool) {
+	family := data[0]
+	dstLen := int(data[1])
+	protocol, scope, routeType := data[5], data[6], data[7]
+	attrs := netlinkAttributeMap(data[unix.SizeofRtMsg:])
+	if family == unix.AF_UNSPEC {
+		// Some network stacks leave the family of default routes unset.
+		switch len(attrs[unix.RTA_GATEWAY]) {
+		case net.IPv4len:
+			family = unix.AF_INET
+		case net.IPv6len:
+			family = unix.AF_INET6
+		}
+	}
+	if family != unix.AF_INET && family != unix.AF_INET6 {
+		return anynetwork.Route{}, false
+	}
+
+	tableID := uint32(data[4])
+	if table := attrs[unix.RTA_TABLE]; len(table) >= 4 {
+		tableID = binary.NativeEndian.Uint32(table)
+	}
+	route := anynetwork.Route{
+		Family:          familyName(uint32(family)),
+		Destination:     prefixString(attrs[unix.RTA_DST], dstLen),
+		Gateway:         ipString(attrs[unix.RTA_GATEWAY]),
+		PreferredSource: ipString(attrs[unix.RTA_PREFSRC]),
+		Metric:          attributeUint32(attrs[unix.RTA_PRIORITY]),
+		Table:           routeTableName(tableID),
+		TableID:         tableID,
+		Protocol:        routeProtocolNames[protocol],
+		Scope:           routeScopeNames[scope],
+		Type:            routeTypeNames[routeType],
+	}
+	if route.Destination == "" {
+		route.Destination = "0.0.0.0/0"
+		if family == unix.AF_INET6 {
+			route.Destination = "::/0"
+		}
+		route.IsDefault = routeType == unix.RTN_UNICAST
+	}
+	if route.Protocol == "" {
+		route.Protocol = strconv.Itoa(int(protocol))
+	}
+	if oif := attrs[unix.RTA_OIF]; len(oif) >= 4 {
+		route.InterfaceIndex = int(int32(binary.NativeEndian.Uint32(oif)))
+		route.Interface = interfaceName(route.InterfaceIndex)
+	}
+	route.Nexthops = parseMultipath(attrs[unix.RTA_MULTIPATH], interfaceName)
+	return route, true
+}
+
+// parseMultipath parses the rtnexthop structures of an RTA_MULTIPATH attribute.
+func parseMultipath(data []byte, interfaceName func(int) string) []anynetwork.RouteNexthop {
+	var nexthops []anynetwork.RouteNexthop
+	for len(data) >= unix.SizeofRtNexthop {
+		length := int(binary.NativeEndian.Uint16(data[0:2]))
+		if length < unix.SizeofRtNexthop || length > len(data) {
+			break
+		}
+		ifIndex := int(int32(binary.NativeEndian.Uint32(data[4:8])))
+		attrs := netlinkAttributeMap(data[unix.SizeofRtNexthop:length])
+		nexthops = append(nexthops, anynetwork.RouteNexthop{
+			Gateway:        ipString(attrs[unix.RTA_GATEWAY]),
+			Interface:      interfaceName(ifIndex),
+			InterfaceIndex: ifIndex,
+			Weight:         int(data[3]) + 1, // rtnh_hops holds weight - 1
+		})
+		aligned := netlinkAlign(length)
+		if aligned > len(data) {
+			break
+		}
+		data = data[aligned:]
+	}
+	return nexthops
+}
+
+// parseRuleMessage parses a fib_rule_hdr with its attributes.
+func parseRuleMessage(data []byte) (anynetwork.RoutingRule, bool) {
+	family := data[0]
+	if family != unix.AF_INET && family != unix.AF_INET6 {
+		return anynetwork.RoutingRule{}, false
+	}
+	dstLen, srcLen := int(data[1]), int(data[2])
+	action := data[7]
+	flags := binary.NativeEndian.Uint32(data[8:12])
+	attrs := netlinkAttributeMap(data[fibRuleHdrLen:])
+
+	tableID := uint32(data[4])
+	if table := attrs[unix.FRA_TABLE]; len(table) >= 4 {
+		tableID = binary.NativeEndian.Uint32(table)
+	}
+	rule := anynetwork.RoutingRule{
+		Family:          familyName(uint32(family)),
+		Source:          prefixString(attrs[unix.FRA_SRC], srcLen),
+		Destination:     prefixString(attrs[unix.FRA_DST], dstLen),
+		InputInterface:  strings.TrimRight(string(attrs[unix.FRA_IIFNAME]), "\x00"),
+		OutputInterface: strings.TrimRight(string(attrs[unix.FRA_OIFNAME]), "\x00"),
+		FwMark:          attributeUint32(attrs[unix.FRA_FWMARK]),
+		FwMask:          attributeUint32(attrs[unix.FRA_FWMASK]),
+		Priority:        attributeUint32(attrs[unix.FRA_PRIORITY]),
+		Invert:          flags&unix.FIB_RULE_INVERT != 0,
+		Action:          ruleActionNames[action],
+		Goto:            attributeUint32(attrs[unix.FRA_GOTO]),
+		SuppressPrefix:  -1,
+	}
+	if action == unix.FR_ACT_TO_TBL {
+		rule.TableID = tableID
+		rule.Table = routeTableName(tableID)
+	}
+	if suppress := attrs[unix.FRA_SUPPRESS_PREFIXLEN]; len(suppress) >= 4 {
+		rule.SuppressPrefix = int(int32(binary.NativeEndian.Uint32(suppress)))
+	}
+	if rule.Action == "" {
+		rule.Action = strconv.Itoa(int(action))
+	}
+	return rule, true
+}
+
+// prefixString formats an address attribute and prefix length as CIDR; it returns an
+// empty string for a missing address.
+func prefixString(addr []byte, prefixLen int) string {
+	ip := ipString(addr)
+	if ip == "" {
+		return ""
+	}
+	return ip + "/" + strconv.Itoa(prefixLen)
+}
+
+func ipString(addr []byte) string {
+	if len(addr) != net.IPv4len && len(addr) != net.IPv6len {
+		return ""
+	}
+	return net.IP(addr).String()
+}
+
+func attributeUint32(value []byte) uint32 {
+	if len(value) < 4 {
+		return 0
+	}
+	return binary.NativeEndian.Uint32(value)
+}
+
+func routeTableName(tableID uint32) string {
+	if name, ok := routeTableNames[tableID]; ok {
+		return name
+	}
+	return strconv.FormatUint(uint64(tableID), 10)
+}
@@ -0,0 +1,12 @@
+//go:build !linux
+
+package network
+
+import (
+	anynetwork "privacy-buddy/backend/network"
+)
+
+// NewRoutingService returns a service that reports the routing table as not supported.
+func NewRoutingService() anynetwork.RoutingService {
+	return anynetwork.UnsupportedRoutingService{}
+}
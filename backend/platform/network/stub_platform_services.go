@@ -0,0 +1,27 @@
+//go:build !linux && !windows && !darwin
+
+package network
+
+import (
+	anynetwork "privacy-buddy/backend/network"
+)
+
+// NewARPCacheService returns a service that reports the ARP cache as not supported.
+func NewARPCacheService() anynetwork.ARPCacheService {
+	return anynetwork.UnsupportedARPCacheService{}
+}
+
+// NewNetworkInterfaceService returns a service that reports interface listing as not supported.
+func NewNetworkInterfaceService() anynetwork.NetworkInterfaceService {
+	return anynetwork.UnsupportedNetworkInterfaceService{}
+}
+
+// NewNetworkConnectionService returns a service that reports connection listing as not supported.
+func NewNetworkConnectionService() anynetwork.NetworkConnectionService {
+	return anynetwork.UnsupportedNetworkConnectionService{}
+}
+
+// NewPacketCaptureService returns a service that reports packet capture as not supported.
+func NewPacketCaptureService() anynetwork.PacketCaptureService {
+	return anynetwork.UnsupportedPacketCaptureService{}
+}
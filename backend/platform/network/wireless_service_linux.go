@@ -0,0 +1,362 @@
+//go:build linux
+
+package network
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	anynetwork "privacy-buddy/backend/network"
+)
+
+// Information element IDs and values of IEEE 802.11.
+const (
+	ieSSID             = 0
+	ieRSN              = 48
+	ieVendorSpecific   = 221
+	capabilityPrivacy  = 0x0010
+	rsnSuiteOUI        = 0x000FAC // IEEE 802.11 suite selector OUI
+	wpaVendorOUI       = 0x0050F2 // Microsoft OUI of the WPA1 vendor element
+	wpaVendorTypeWPA   = 1
+	procNetWirelessHdr = 2 // Header lines of /proc/net/wireless
+)
+
+// wirelessModes names the NL80211_IFTYPE_* values.
+var wirelessModes = map[uint32]string{
+	1:  "adhoc",
+	2:  "station",
+	3:  "ap",
+	4:  "ap-vlan",
+	5:  "wds",
+	6:  "monitor",
+	7:  "mesh",
+	8:  "p2p-client",
+	9:  "p2p-go",
+	10: "p2p-device",
+	11: "ocb",
+	12: "nan",
+}
+
+// LinuxWirelessService reads Wi-Fi details with nl80211, falling back to /proc/net/wireless.
+type LinuxWirelessService struct{}
+
+// GetWirelessInfo returns the Wi-Fi connection of every wireless interface.
+func (s *LinuxWirelessService) GetWirelessInfo() ([]anynetwork.WirelessInfo, error) {
+	infos, err := readWirelessNL80211()
+	if err == nil {
+		return infos, nil
+	}
+	log.Printf("WARN: nl80211 query failed, falling back to /proc/net/wireless: %v", err)
+	infos, procErr := readProcNetWireless("/proc/net/wireless")
+	if procErr != nil {
+		return nil, fmt.Errorf("failed to get Wi-Fi details: %w", err)
+	}
+	return infos, nil
+}
+
+// NewWirelessService creates the Linux implementation of WirelessService.
+func NewWirelessService() anynetwork.WirelessService {
+	return &LinuxWirelessService{}
+}
+
+// readWirelessNL80211 combines the interface, station, scan and survey dumps of nl80211.
+func readWirelessNL80211() ([]anynetwork.WirelessInfo, error) {
+	family, err := genetlinkFamilyID("nl80211")
+	if err != nil {
+		return nil, err
+	}
+	interfaces, err := genetlinkDump(family, unix.NL80211_CMD_GET_INTERFACE)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wireless interfaces: %w", err)
+	}
+
+	var infos []anynetwork.WirelessInfo
+	for _, attrs := range interfaces {
+		ifIndex := attrs[unix.NL80211_ATTR_IFINDEX]
+		if len(ifIndex) < 4 {
+			continue // e.g., P2P devices without netdev
+		}
+		info := anynetwork.WirelessInfo{
+			Interface: strings.TrimRight(string(attrs[unix.NL80211_ATTR_IFNAME]), "\x00"),
+			SSID:      string(attrs[unix.NL80211_ATTR_SSID]),
+			Source:    "nl80211",
+		}
+		if mode := attrs[unix.NL80211_ATTR_IFTYPE]; len(mode) >= 4 {
+			info.Mode = wirelessModes[binary.NativeEndian.Uint32(mode)]
+		}
+		if freq := attrs[unix.NL80211_ATTR_WIPHY_FREQ]; len(freq) >= 4 {
+			setWirelessFrequency(&info, int(binary.NativeEndian.Uint32(freq)))
+		}
+
+		indexAttr := netlinkAttributeBytes(unix.NL80211_ATTR_IFINDEX, ifIndex[:4])
+		if info.Mode == "station" || info.Mode == "p2p-client" {
+			// In AP, P2P-GO or mesh mode the stations are the clients, not an access point
+			addStationInfo(family, indexAttr, &info)
+		}
+		addBSSInfo(family, indexAttr, &info)
+		addSurveyInfo(family, indexAttr, &info)
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Interface < infos[j].Interface })
+	return infos, nil
+}
+
+// addStationInfo adds BSSID, signal and bitrates of the access point a station is associated with.
+// It must only be used for interfaces in station mode, which have at most one station entry.
+func addStationInfo(family uint16, indexAttr []byte, info *anynetwork.WirelessInfo) {
+	stations, err := genetlinkDump(family, unix.NL80211_CMD_GET_STATION, indexAttr)
+	if err != nil || len(stations) == 0 {
+		return
+	}
+	station := stations[0]
+	if mac := station[unix.NL80211_ATTR_MAC]; len(mac) == 6 {
+		info.BSSID = net.HardwareAddr(mac).String()
+		info.Connected = true
+	}
+	staInfo := netlinkAttributeMap(station[unix.NL80211_ATTR_STA_INFO])
+	if signal := staInfo[unix.NL80211_STA_INFO_SIGNAL]; len(signal) >= 1 {
+		info.SignalDBm = int(int8(signal[0]))
+	}
+	info.TxBitrateMbps = bitrateMbps(staInfo[unix.NL80211_STA_INFO_TX_BITRATE])
+	info.RxBitrateMbps = bitrateMbps(staInfo[unix.NL80211_STA_INFO_RX_BITRATE])
+}
+
+// addBSSInfo adds SSID, frequency and security of the associated BSS from the scan results.
+func addBSSInfo(family uint16, indexAttr []byte, info *anynetwork.WirelessInfo) {
+	results, err := genetlinkDump(family, unix.NL80211_CMD_GET_SCAN, indexAttr)
+	if err != nil {
+		return
+	}
+	for _, result := range results {
+		bss := netlinkAttributeMap(result[unix.NL80211_ATTR_BSS])
+		bssid := ""
+		if mac := bss[unix.NL80211_BSS_BSSID]; len(mac) == 6 {
+			bssid = net.HardwareAddr(mac).String()
+		}
+		status, hasStatus := bss[unix.NL80211_BSS_STATUS]
+		associated := hasStatus && len(status) >= 4 && binary.NativeEndian.Uint32(status) == unix.NL80211_BSS_STATUS_ASSOCIATED
+		if !associated && (bssid == "" || bssid != info.BSSID) {
+			continue
+		}
+
+		info.Connected = true
+		if info.BSSID == "" {
+			info.BSSID = bssid
+		}
+		if freq := bss[unix.NL80211_BSS_FREQUENCY]; len(freq) >= 4 && info.FrequencyMHz == 0 {
+			setWirelessFrequency(info, int(binary.NativeEndian.Uint32(freq)))
+		}
+		if signal := bss[unix.NL80211_BSS_SIGNAL_MBM]; len(signal) >= 4 && info.SignalDBm == 0 {
+			info.SignalDBm = int(int32(binary.NativeEndian.Uint32(signal))) / 100
+		}
+		var capability uint16
+		if c := bss[unix.NL80211_BSS_CAPABILITY]; len(c) >= 2 {
+			capability = binary.NativeEndian.Uint16(c)
+		}
+		ies := bss[unix.NL80211_BSS_INFORMATION_ELEMENTS]
+		if info.SSID == "" {
+			info.SSID = informationElementSSID(ies)
+		}
+		info.Security = wirelessSecurity(ies, capability)
+		info.IsOpen = info.Security == "open"
+		return
+	}
+}
+
+// addSurveyInfo adds the noise floor of the channel in use, if the driver reports it.
+func addSurveyInfo(family uint16, indexAttr []byte, info *anynetwork.WirelessInfo) {
+	surveys, err := genetlinkDump(family, unix.NL80211_CMD_GET_SURVEY, indexAttr)
+	if err != nil {
+		return
+	}
+	for _, survey := range surveys {
+		surveyInfo := netlinkAttributeMap(survey[unix.NL80211_ATTR_SURVEY_INFO])
+		if _, inUse := surveyInfo[unix.NL80211_SURVEY_INFO_IN_USE]; !inUse {
+			continue
+		}
+		if noise := surveyInfo[unix.NL80211_SURVEY_INFO_NOISE]; len(noise) >= 1 {
+			info.NoiseDBm = int(int8(noise[0]))
+		}
+		return
+	}
+}
+
+// bitrateMbps decodes a nested NL80211_STA_INFO_*_BITRATE attribute (units of 100 kbit/s).
+func bitrateMbps(data []byte) float64 {
+	rate := netlinkAttributeMap(data)
+	if r := rate[unix.NL80211_RATE_INFO_BITRATE32]; len(r) >= 4 {
+		return float64(binary.NativeEndian.Uint32(r)) / 10
+	}
+	if r := rate[unix.NL80211_RATE_INFO_BITRATE]; len(r) >= 2 {
+		return float64(binary.NativeEndian.Uint16(r)) / 10
+	}
+	return 0
+}
+
+// setWirelessFrequency sets frequency, channel and band.
+func setWirelessFrequency(info *anynetwork.WirelessInfo, freq int) {
+	info.FrequencyMHz = freq
+	switch {
+	case freq == 2484:
+		info.Channel, info.Band = 14, "2.4 GHz"
+	case freq >= 2412 && freq < 2484:
+		info.Channel, info.Band = (freq-2407)/5, "2.4 GHz"
+	case freq >= 5955 && freq <= 7115:
+		info.Channel, info.Band = (freq-5950)/5, "6 GHz"
+	case freq >= 4910 && freq <= 4980:
+		info.Channel, info.Band = (freq-4000)/5, "5 GHz" // 4.9 GHz public safety band, channels 182-196
+	case freq >= 5000 && freq <= 5895:
+		info.Channel, info.Band = (freq-5000)/5, "5 GHz"
+	case freq >= 58320 && freq <= 70200:
+		info.Channel, info.Band = (freq-56160)/2160, "60 GHz"
+	}
+}
+
+// informationElements splits the 802.11 information elements into ID and payload.
+func informationElements(data []byte) map[byte][][]byte {
+	elements := make(map[byte][][]byte)
+	for len(data) >= 2 {
+		id, length := data[0], int(data[1])
+		if len(data) < 2+length {
+			break
+		}
+		elements[id] = append(elements[id], data[2:2+length])
+		data = data[2+length:]
+	}
+	return elements
+}
+
+func informationElementSSID(ies []byte) string {
+	if ssid := informationElements(ies)[ieSSID]; len(ssid) > 0 {
+		return string(ssid[0])
+	}
+	return ""
+}
+
+// wirelessSecurity derives the security mode from the RSN and WPA elements and the
+// privacy capability bit of a BSS.
+func wirelessSecurity(ies []byte, capability uint16) string {
+	elements := informationElements(ies)
+	if rsn := elements[ieRSN]; len(rsn) > 0 {
+		return rsnSecurity(rsnAKMSuites(rsn[0]))
+	}
+	for _, vendor := range elements[ieVendorSpecific] {
+		if len(vendor) >= 4 && suiteOUI(vendor) == wpaVendorOUI && vendor[3] == wpaVendorTypeWPA {
+			return "WPA"
+		}
+	}
+	if capability&capabilityPrivacy != 0 {
+		return "WEP"
+	}
+	return "open"
+}
+
+// rsnAKMSuites returns the authentication and key management suite types of an RSN element
+// with the IEEE OUI.
+func rsnAKMSuites(rsn []byte) []byte {
+	// Version (2), group cipher (4), pairwise count (2) + suites, AKM count (2) + suites.
+	if len(rsn) < 8 {
+		return nil
+	}
+	pairwise := int(binary.LittleEndian.Uint16(rsn[6:8]))
+	offset := 8 + 4*pairwise
+	if len(rsn) < offset+2 {
+		return nil
+	}
+	count := int(binary.LittleEndian.Uint16(rsn[offset : offset+2]))
+	offset += 2
+
+	var suites []byte
+	for i := 0; i < count && len(rsn) >= offset+4; i++ {
+		if suiteOUI(rsn[offset:]) == rsnSuiteOUI {
+			suites = append(suites, rsn[offset+3])
+		}
+		offset += 4
+	}
+	return suites
+}
+
+// suiteOUI returns the OUI at the start of a suite selector or vendor element.
+func suiteOUI(data []byte) uint32 {
+	return uint32(data[0])<<16 | uint32(data[1])<<8 | uint32(data[2])
+}
+
+// rsnSecurity maps AKM suite types (IEEE 802.11 table 9-151) to a security mode.
+func rsnSecurity(suites []byte) string {
+	var psk, sae, enterprise, suiteB, owe bool
+	for _, suite := range suites {
+		switch suite {
+		case 1, 3, 5:
+			enterprise = true
+		case 2, 4, 6:
+			psk = true
+		case 8, 9, 24, 25:
+			sae = true
+		case 11, 12, 13:
+			suiteB = true
+		case 18:
+			owe = true
+		}
+	}
+	switch {
+	case suiteB:
+		return "WPA3-Enterprise"
+	case enterprise:
+		return "WPA2-Enterprise"
+	case sae && psk:
+		return "WPA2/WPA3"
+	case sae:
+		return "WPA3"
+	case psk:
+		return "WPA2"
+	case owe:
+		return "OWE"
+	}
+	return "WPA2"
+}
+
+// readProcNetWireless parses lines like
+// " wlan0: 0000   54.  -56.  -256        0      0      0      0     12        0".
+// Signal and noise are reported in dBm by all current drivers; -256 means unknown.
+func readProcNetWireless(path string) ([]anynetwork.WirelessInfo, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var infos []anynetwork.WirelessInfo
+	scanner := bufio.NewScanner(file)
+	for line := 0; scanner.Scan(); line++ {
+		if line < procNetWirelessHdr {
+			continue
+		}
+		name, rest, ok := strings.Cut(scanner.Text(), ":")
+		fields := strings.Fields(rest)
+		if !ok || len(fields) < 4 {
+			continue
+		}
+		info := anynetwork.WirelessInfo{Interface: strings.TrimSpace(name), Source: "proc"}
+		if signal, err := strconv.ParseFloat(strings.TrimSuffix(fields[2], "."), 64); err == nil && signal > -256 {
+			info.SignalDBm = int(signal)
+			info.Connected = signal != 0
+		}
+		if noise, err := strconv.ParseFloat(strings.TrimSuffix(fields[3], "."), 64); err == nil && noise > -256 {
+			info.NoiseDBm = int(noise)
+		}
+		infos = append(infos, info)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return infos, nil
+}
@@ -0,0 +1,154 @@
+//go:build linux
+
+package network
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	anynetwork "privacy-buddy/backend/network"
+)
+
+func TestSetWirelessFrequency(t *testing.T) {
+	tests := []struct {
+		freq        int
+		wantChannel int
+		wantBand    string
+	}{
+		{2412, 1, "2.4 GHz"},
+		{2437, 6, "2.4 GHz"},
+		{2472, 13, "2.4 GHz"},
+		{2484, 14, "2.4 GHz"},
+		{4920, 184, "5 GHz"},
+		{4940, 188, "5 GHz"},
+		{4980, 196, "5 GHz"},
+		{5180, 36, "5 GHz"},
+		{5500, 100, "5 GHz"},
+		{5825, 165, "5 GHz"},
+		{5955, 1, "6 GHz"},
+		{6415, 93, "6 GHz"},
+		{7115, 233, "6 GHz"},
+		{58320, 1, "60 GHz"},
+		{62640, 3, "60 GHz"},
+		{70200, 6, "60 GHz"},
+		{900, 0, ""},
+	}
+
+	for _, tt := range tests {
+		var info anynetwork.WirelessInfo
+		setWirelessFrequency(&info, tt.freq)
+		if info.FrequencyMHz != tt.freq || info.Channel != tt.wantChannel || info.Band != tt.wantBand {
+			t.Errorf("setWirelessFrequency(%d) = channel %d, band %q; want channel %d, band %q",
+				tt.freq, info.Channel, info.Band, tt.wantChannel, tt.wantBand)
+		}
+	}
+}
+
+// rsnElement builds an RSN element body with a CCMP group and pairwise cipher and the AKM suites.
+func rsnElement(akmSuites ...byte) []byte {
+	rsn := []byte{
+		0x01, 0x00, // Version
+		0x00, 0x0F, 0xAC, 0x04, // Group cipher CCMP
+		0x01, 0x00, // Pairwise cipher count
+		0x00, 0x0F, 0xAC, 0x04, // CCMP
+		byte(len(akmSuites)), 0x00, // AKM suite count
+	}
+	for _, suite := range akmSuites {
+		rsn = append(rsn, 0x00, 0x0F, 0xAC, suite)
+	}
+	return append(rsn, 0x00, 0x00) // RSN capabilities
+}
+
+func TestRSNSecurity(t *testing.T) {
+	tests := []struct {
+		name       string
+		rsn        []byte
+		wantSuites []byte
+		want       string
+	}{
+		{"PSK", rsnElement(2), []byte{2}, "WPA2"},
+		{"PSK-SHA256", rsnElement(6), []byte{6}, "WPA2"},
+		{"SAE", rsnElement(8), []byte{8}, "WPA3"},
+		{"PSK and SAE transition", rsnElement(2, 8), []byte{2, 8}, "WPA2/WPA3"},
+		{"802.1X", rsnElement(1), []byte{1}, "WPA2-Enterprise"},
+		{"802.1X Suite B", rsnElement(12), []byte{12}, "WPA3-Enterprise"},
+		{"OWE", rsnElement(18), []byte{18}, "OWE"},
+		{"vendor AKM", append(rsnElement()[:12], 0x01, 0x00, 0x00, 0x40, 0x96, 0x01), nil, "WPA2"},
+		{"truncated in the AKM list", rsnElement(8)[:16], nil, "WPA2"},
+		{"truncated in the pairwise list", rsnElement(2)[:10], nil, "WPA2"},
+		{"version only", []byte{0x01, 0x00}, nil, "WPA2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suites := rsnAKMSuites(tt.rsn)
+			if !bytes.Equal(suites, tt.wantSuites) {
+				t.Errorf("rsnAKMSuites() = %v, want %v", suites, tt.wantSuites)
+			}
+			if got := rsnSecurity(suites); got != tt.want {
+				t.Errorf("rsnSecurity(%v) = %q, want %q", suites, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWirelessSecurity(t *testing.T) {
+	ssid := []byte{ieSSID, 4, 'h', 'o', 'm', 'e'}
+	rsn := append([]byte{ieRSN, byte(len(rsnElement(8)))}, rsnElement(8)...)
+	wpa := []byte{ieVendorSpecific, 4, 0x00, 0x50, 0xF2, wpaVendorTypeWPA}
+
+	tests := []struct {
+		name       string
+		ies        []byte
+		capability uint16
+		want       string
+	}{
+		{"RSN", append(append([]byte{}, ssid...), rsn...), capabilityPrivacy, "WPA3"},
+		{"WPA vendor element", append(append([]byte{}, ssid...), wpa...), capabilityPrivacy, "WPA"},
+		{"privacy bit only", ssid, capabilityPrivacy, "WEP"},
+		{"open", ssid, 0, "open"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wirelessSecurity(tt.ies, tt.capability); got != tt.want {
+				t.Errorf("wirelessSecurity() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+	if got := informationElementSSID(ssid); got != "home" {
+		t.Errorf("informationElementSSID() = %q, want %q", got, "home")
+	}
+}
+
+// procNetWireless is /proc/net/wireless with a connected, a disconnected and a malformed interface.
+const procNetWireless = `Inter-| sta-|   Quality        |   Discarded packets               | Missed | WE
+ face | tus | link level noise |  nwid  crypt   frag  retry   misc | beacon | 22
+ wlan0: 0000   54.  -56.  -256        0      0      0      0     12        0
+wlp3s0: 0000    0.    0.     0.       0      0      0      0      0        0
+ wlan1: 0000   70.  -40.   -95.       0      0      0      0      0        0
+broken line
+`
+
+func TestReadProcNetWireless(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wireless")
+	if err := os.WriteFile(path, []byte(procNetWireless), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := readProcNetWireless(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []anynetwork.WirelessInfo{
+		{Interface: "wlan0", Source: "proc", SignalDBm: -56, Connected: true},
+		{Interface: "wlp3s0", Source: "proc"},
+		{Interface: "wlan1", Source: "proc", SignalDBm: -40, NoiseDBm: -95, Connected: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readProcNetWireless() =\n%+v\nwant\n%+v", got, want)
+	}
+}
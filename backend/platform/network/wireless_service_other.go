@@ -0,0 +1,12 @@
+//go:build !linux
+
+package network
+
+import (
+	anynetwork "privacy-buddy/backend/network"
+)
+
+// NewWirelessService returns a service that reports Wi-Fi details as not supported.
+func NewWirelessService() anynetwork.WirelessService {
+	return anynetwork.UnsupportedWirelessService{}
+}
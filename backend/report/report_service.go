@@ -11,13 +11,15 @@ import (
 type ReportService struct {
 	systemSvc  *system.SystemService
 	networkSvc *network.NetworkDashboardService
+	auditSvc   *network.ExposureAuditService
 }
 
 // NewReportService erstellt eine neue Instanz des ReportService.
-func NewReportService(systemSvc *system.SystemService, networkSvc *network.NetworkDashboardService) *ReportService {
+func NewReportService(systemSvc *system.SystemService, networkSvc *network.NetworkDashboardService, auditSvc *network.ExposureAuditService) *ReportService {
 	return &ReportService{
 		systemSvc:  systemSvc,
 		networkSvc: networkSvc,
+		auditSvc:   auditSvc,
 	}
 }
 
@@ -26,6 +28,12 @@ type ReportData struct {
 	SystemInfo *system.SystemInfo `json:"systemInfo"`
 	PublicIP   string             `json:"publicIP"`
 	LocalIP    string             `json:"localIP"`
+
+	DefaultGateways []network.DefaultGateway `json:"defaultGateways,omitempty"` // IPv4- und IPv6-Standardgateways
+
+	InterfaceAddresses map[string][]network.InterfaceAddress `json:"interfaceAddresses,omitempty"` // Adressen je Schnittstelle mit Geltungsbereich (host/link/global)
+
+	ListeningPorts *network.ExposureAudit `json:"listeningPorts,omitempty"` // Fehlt, wenn die Prüfung fehlschlägt
 }
 
 // GenerateReport sammelt alle relevanten Informationen und gibt sie als JSON-String zurück.
@@ -41,6 +49,22 @@ func (s *ReportService) GenerateReport() (string, error) {
 		PublicIP:   s.networkSvc.GetPublicIP(),
 		LocalIP:    s.networkSvc.GetLocalIP(),
 	}
+	if gateways, err := s.networkSvc.GetDefaultGateways(); err == nil {
+		data.DefaultGateways = gateways
+	}
+	if interfaces, err := s.networkSvc.GetNetworkInterfaces(); err == nil {
+		data.InterfaceAddresses = make(map[string][]network.InterfaceAddress)
+		for _, iface := range interfaces {
+			if len(iface.Addresses) > 0 {
+				data.InterfaceAddresses[iface.Name] = iface.Addresses
+			}
+		}
+	}
+	if s.auditSvc != nil {
+		if audit, err := s.auditSvc.AuditListeningPorts(); err == nil {
+			data.ListeningPorts = audit
+		}
+	}
 
 	jsonData, err := json.MarshalIndent(data, "", "  ")
 	if err != nil {
@@ -27,12 +27,23 @@ function formatMacAddress(mac) {
   }
 }
 
+function formatInterfaceAddresses(iface) {
+  if (!iface.Addresses || iface.Addresses.length === 0) {
+    return (iface.Addrs || []).join('<br>') || '-';
+  }
+  return iface.Addresses.map(a => {
+    const flags = (a.Flags || []).filter(f => ['temporary', 'deprecated', 'tentative', 'dadfailed'].includes(f));
+    const details = [a.Scope, ...flags].filter(Boolean).join(', ');
+    return `${a.IP}/${a.PrefixLength}${details ? ` <small>(${details})</small>` : ''}`;
+  }).join('<br>');
+}
+
 function generateInterfaceTable(interfaces, externalInterfaceName) {
   console.debug('[generateInterfaceTable] externalInterfaceName:', externalInterfaceName);
 
   if (!interfaces || interfaces.length === 0) return '<p>No network interfaces found.</p>';
 
-  const headers = ['Name', 'Description', 'MAC Address', 'IP Addresses', 'Status'];
+  const headers = ['Name', 'Description', 'MAC Address', 'IP Addresses', 'Status', 'Capture'];
   let table = '<table><thead><tr>' + headers.map(h => `<th>${h}</th>`).join('') + '</tr></thead><tbody>';
 
   interfaces.forEach(iface => {
@@ -44,8 +55,9 @@ function generateInterfaceTable(interfaces, externalInterfaceName) {
     const isUp = iface.IsUp;
     const statusText = isUplink ? '⚡ Uplink' : (isUp ? '🟢 Up' : '🔴 Down');
     const rowClass = isUplink ? 'uplink-interface' : (isUp ? 'up-interface' : 'down-interface');
+    const captureText = iface.CanCapture ? '✔' : `✖ ${iface.CaptureUnavailableReason || ''}`;
 
-    table += `<tr class="${rowClass}"><td>${name}</td><td>${description}</td><td>${formatMacAddress(iface.HardwareAddr) || '-'}</td><td>${(iface.Addrs || []).join('<br>') || '-'}</td><td>${statusText}</td></tr>`;
+    table += `<tr class="${rowClass}"><td>${name}</td><td>${description}</td><td>${formatMacAddress(iface.HardwareAddr) || '-'}</td><td>${formatInterfaceAddresses(iface)}</td><td>${statusText}</td><td>${captureText}</td></tr>`;
   });
 
   return table + '</tbody></table>';
@@ -63,8 +75,12 @@ function populateInterfaceDropdown(selectElement, interfaces) {
 
   interfaces.forEach(iface => {
     const option = document.createElement('option');
-    option.value = iface.Name;
+    option.value = iface.CaptureName || iface.Name;
     option.textContent = iface.DisplayName || iface.Name;
+    if (!iface.CanCapture) {
+      option.disabled = true;
+      option.title = iface.CaptureUnavailableReason || '';
+    }
     selectElement.appendChild(option);
   });
 }
@@ -23,12 +23,26 @@ var assets embed.FS
 func main() {
 	appsvcInstance := appsvc.NewApp()
 	systemSvc := &system.SystemService{}
-	networkSvc := &anynetwork.NetworkDashboardService{}
-	reportSvc := report.NewReportService(systemSvc, networkSvc)
+	platformSvcs := platform_network.NewPlatformServices()
+	networkSvc := anynetwork.NewNetworkDashboardService(platformSvcs)
+	exposureAuditSvc := anynetwork.NewExposureAuditService(platformSvcs.Connections)
+	reportSvc := report.NewReportService(systemSvc, networkSvc, exposureAuditSvc)
 
 	tracerouteSvc := platform_network.NewTracerouteService()
-	networkToolsSvc := anynettools.NewNetworkToolsService(tracerouteSvc)
+	networkToolsSvc := anynettools.NewNetworkToolsService(tracerouteSvc, networkSvc)
 	advancedNetworkToolsSvc := anynettools.GetAdvancedNetworkToolsService() // ✅ holt Singleton
+	trackerSvc := anynetwork.NewTrackerService(platformSvcs.Connections)
+	reverseDNSSvc := anynetwork.GetReverseDNSService()
+	vendorSvc := anynetwork.GetVendorService()
+	deviceInventorySvc := anynetwork.GetDeviceInventoryService()
+	arpWatchSvc := anynettools.NewARPWatchService(platformSvcs.ARPCache)
+	arpScanSvc := anynettools.NewARPScanService()
+	connectionWatchSvc := anynetwork.NewConnectionWatchService(platformSvcs.Connections)
+	processTrafficSvc := anynettools.NewProcessTrafficService(platformSvcs.Connections)
+	connectionHistorySvc := anynetwork.NewConnectionHistoryService(platformSvcs.Connections)
+	interfaceStatsSvc := anynetwork.NewInterfaceStatsService(platformSvcs.InterfaceCounters)
+	networkChangeSvc := anynetwork.NewNetworkChangeService(platformSvcs.ChangeMonitor, platformSvcs.DNSConfig)
+	advancedNetworkToolsSvc.SetTrackerService(trackerSvc, platformSvcs.Connections)
 
 	// ✅ Korrekte Initialisierung über Konstruktor
 
@@ -46,6 +60,22 @@ func main() {
 
 			// 👇 Wichtig: Singleton bekommt seinen Context
 			advancedNetworkToolsSvc.WailsInit(ctx)
+			networkSvc.WailsInit(ctx)
+			trackerSvc.WailsInit(ctx)
+			reverseDNSSvc.WailsInit(ctx)
+			deviceInventorySvc.WailsInit(ctx)
+			arpWatchSvc.WailsInit(ctx)
+			arpScanSvc.WailsInit(ctx)
+			connectionWatchSvc.WailsInit(ctx)
+			processTrafficSvc.WailsInit(ctx)
+			connectionHistorySvc.WailsInit(ctx)
+			interfaceStatsSvc.WailsInit(ctx)
+			networkChangeSvc.WailsInit(ctx)
+		},
+		OnShutdown: func(ctx context.Context) {
+			connectionHistorySvc.Shutdown()
+			networkChangeSvc.Shutdown()
+			deviceInventorySvc.Shutdown()
 		},
 		Bind: []interface{}{
 			appsvcInstance,
@@ -57,6 +87,18 @@ func main() {
 			reportSvc,
 			networkToolsSvc,
 			advancedNetworkToolsSvc, // ✅ Jetzt korrekt initialisiert
+			trackerSvc,
+			reverseDNSSvc,
+			vendorSvc,
+			deviceInventorySvc,
+			arpWatchSvc,
+			arpScanSvc,
+			connectionWatchSvc,
+			processTrafficSvc,
+			exposureAuditSvc,
+			connectionHistorySvc,
+			interfaceStatsSvc,
+			networkChangeSvc,
 		},
 	})
 